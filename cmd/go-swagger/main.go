@@ -0,0 +1,276 @@
+// go-swagger generates swagger.json from the source of a service without running it.
+//
+// Usage:
+//
+//	go-swagger <command> [flags]
+//
+// The commands are:
+//
+//	init   generate swagger.json into the output dir
+//	fmt    write the generated annotations back to the handlers
+//	check  report whether swagger.json is out of date
+//	serve  serve the swagger ui of swagger.json
+//
+// The flags can also be set by a json config file, -config go-swagger.json by default,
+// whose keys are the fields of parser.SwaggerConfig, e.g. {"ParseDirs": ["./api"]}.
+// The flags set explicitly override the config file.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Scterl/go-swagger/parser"
+)
+
+// DefaultConfigFile the config file read when -config is not set.
+const DefaultConfigFile = "go-swagger.json"
+
+type config struct {
+	parser.SwaggerConfig
+	// RouteDirs the dirs registering the routes, ParseDirs by default
+	RouteDirs []string
+	// Addr the address served by serve
+	Addr string
+}
+
+// stringList a comma separated flag, setting it replaces the values of the config file.
+type stringList struct {
+	values *[]string
+}
+
+func (list stringList) String() string {
+	if list.values == nil {
+		return ""
+	}
+	return strings.Join(*list.values, ",")
+}
+
+func (list stringList) Set(value string) error {
+	*list.values = make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*list.values = append(*list.values, item)
+		}
+	}
+	return nil
+}
+
+var commands = map[string]func(*config, []string) error{
+	"init":  runInit,
+	"fmt":   runFmt,
+	"check": runCheck,
+	"serve": runServe,
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, exist := commands[os.Args[1]]
+	if !exist {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	conf, args, err := parseConfig(os.Args[1], os.Args[2:])
+	if err != nil {
+		log.Fatalf("[ERROR] %s", err)
+	}
+	if err := command(conf, args); err != nil {
+		log.Fatalf("[ERROR] %s", err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: go-swagger <command> [flags]
+
+commands:
+  init   generate swagger.json into the output dir
+  fmt    write the generated annotations back to the handlers, -d prints the diff only
+  check  report whether swagger.json is out of date, exit with 1 if it is
+  serve  serve the swagger ui of swagger.json
+
+run go-swagger <command> -h for the flags
+`)
+}
+
+// parseConfig reads the config file and the flags, the flags set explicitly override the config file.
+func parseConfig(name string, arguments []string) (*config, []string, error) {
+	conf := &config{
+		SwaggerConfig: parser.SwaggerConfig{
+			ParseDirs:         []string{"."},
+			OutputDir:         "docs",
+			FormatSwaggerJSON: true,
+		},
+		Addr: ":8080",
+	}
+	configFile := DefaultConfigFile
+
+	flags := flag.NewFlagSet("go-swagger "+name, flag.ExitOnError)
+	flags.StringVar(&configFile, "config", configFile, "the json config file")
+	flags.Var(stringList{&conf.ParseDirs}, "dirs", "the comma separated dirs of the handlers")
+	flags.Var(stringList{&conf.RouteDirs}, "routes", "the comma separated dirs registering the routes, -dirs by default")
+	flags.Var(stringList{&conf.BuildTags}, "tags", "the comma separated build tags")
+	flags.StringVar(&conf.Filter, "filter", parser.GinFilter, "the signature of the handlers")
+	flags.StringVar(&conf.OutputDir, "output", conf.OutputDir, "the dir of swagger.json")
+	flags.StringVar(&conf.OpenAPIVersion, "openapi", "", "the version of swagger.json, 2.0 (default), 3.0 or 3.1")
+	flags.BoolVar(&conf.FormatSwaggerJSON, "format", conf.FormatSwaggerJSON, "indent swagger.json")
+	flags.BoolVar(&conf.RequiredByDefault, "required", false, "the fields without omitempty are required")
+	flags.BoolVar(&conf.PrintGenerate, "print", false, "print the handlers with the generated annotations")
+	flags.BoolVar(&conf.Incremental, "incremental", false, "cache the generated annotations in the output dir")
+	flags.IntVar(&conf.CallDepth, "depth", parser.DefaultCallDepth, "the depth of the calls followed from a handler, negative to disable")
+	flags.BoolVar(&conf.DryRun, "d", false, "fmt prints the diff instead of writing the files")
+	flags.StringVar(&conf.Addr, "addr", conf.Addr, "the address served by serve")
+
+	if err := flags.Parse(arguments); err != nil {
+		return nil, nil, err
+	}
+
+	bytes, err := os.ReadFile(configFile)
+	if err != nil {
+		// the default config file is optional
+		if !errors.Is(err, fs.ErrNotExist) || isFlagSet(flags, "config") {
+			return nil, nil, fmt.Errorf("read config file %s, %w", configFile, err)
+		}
+		return conf, flags.Args(), nil
+	}
+	if err := json.Unmarshal(bytes, conf); err != nil {
+		return nil, nil, fmt.Errorf("parse config file %s, %w", configFile, err)
+	}
+
+	// parse the flags again to override the config file
+	if err := flags.Parse(arguments); err != nil {
+		return nil, nil, err
+	}
+
+	return conf, flags.Args(), nil
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// generate discovers the routes statically and generates swagger.json into outputDir.
+func generate(conf *config, outputDir string, writeBack bool) error {
+	routeDirs := conf.RouteDirs
+	if len(routeDirs) == 0 {
+		routeDirs = conf.ParseDirs
+	}
+	source, err := parser.StaticRoutes(routeDirs, conf.BuildTags)
+	if err != nil {
+		return err
+	}
+	routes := source.Routes()
+	if len(routes) == 0 {
+		log.Printf("[WARNING] no route is found in %s", strings.Join(routeDirs, ","))
+	}
+	for _, route := range routes {
+		log.Printf("[INFO] found route %s %s %s", route.Method, route.Path, route.Handler)
+	}
+
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	return parser.ParseDir(source, func(sc *parser.SwaggerConfig) {
+		*sc = conf.SwaggerConfig
+		sc.OutputDir = outputDir
+		sc.WriteBack = writeBack
+		sc.DryRun = writeBack && conf.DryRun
+		// the cache belongs to the output dir
+		sc.Incremental = conf.Incremental && outputDir == conf.OutputDir
+	})
+}
+
+func runInit(conf *config, _ []string) error {
+	if err := generate(conf, conf.OutputDir, false); err != nil {
+		return err
+	}
+	log.Printf("[INFO] generate %s", filepath.Join(conf.OutputDir, "swagger.json"))
+
+	return nil
+}
+
+func runFmt(conf *config, _ []string) error {
+	outputDir, err := os.MkdirTemp("", "go-swagger")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(outputDir)
+
+	return generate(conf, outputDir, true)
+}
+
+func runCheck(conf *config, _ []string) error {
+	outputDir, err := os.MkdirTemp("", "go-swagger")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(outputDir)
+
+	if err := generate(conf, outputDir, false); err != nil {
+		return err
+	}
+
+	fileName := filepath.Join(conf.OutputDir, "swagger.json")
+	equal, err := sameJSON(fileName, filepath.Join(outputDir, "swagger.json"))
+	if err != nil {
+		return err
+	}
+	if !equal {
+		log.Printf("[ERROR] %s is out of date, run go-swagger init", fileName)
+		os.RemoveAll(outputDir)
+		os.Exit(1)
+	}
+	log.Printf("[INFO] %s is up to date", fileName)
+
+	return nil
+}
+
+// sameJSON reports whether two json files have the same content regardless of the format.
+func sameJSON(fileName, generated string) (bool, error) {
+	current, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	expected, err := os.ReadFile(generated)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(current, expected) {
+		return true, nil
+	}
+
+	var currentDoc, expectedDoc interface{}
+	if err := json.Unmarshal(current, &currentDoc); err != nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(expected, &expectedDoc); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(currentDoc, expectedDoc), nil
+}
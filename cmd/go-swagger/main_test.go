@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Scterl/go-swagger/parser"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		arguments  []string
+		expected   config
+		args       []string
+	}{
+		{
+			name:      "defaults without the config file",
+			arguments: []string{"extra"},
+			expected: config{
+				SwaggerConfig: parser.SwaggerConfig{
+					ParseDirs:         []string{"."},
+					OutputDir:         "docs",
+					FormatSwaggerJSON: true,
+					Filter:            parser.GinFilter,
+					CallDepth:         parser.DefaultCallDepth,
+				},
+				Addr: ":8080",
+			},
+			args: []string{"extra"},
+		},
+		{
+			name:       "config file",
+			configFile: `{"ParseDirs": ["./api"], "OutputDir": "swagger", "CallDepth": 1, "Addr": ":9090"}`,
+			expected: config{
+				SwaggerConfig: parser.SwaggerConfig{
+					ParseDirs:         []string{"./api"},
+					OutputDir:         "swagger",
+					FormatSwaggerJSON: true,
+					Filter:            parser.GinFilter,
+					CallDepth:         1,
+				},
+				Addr: ":9090",
+			},
+		},
+		{
+			name:       "flags override the config file",
+			configFile: `{"ParseDirs": ["./api"], "OutputDir": "swagger", "BuildTags": ["dev"], "FormatSwaggerJSON": true}`,
+			arguments:  []string{"-dirs", "./v1, ./v2", "-tags", "prod", "-format=false", "-d"},
+			expected: config{
+				SwaggerConfig: parser.SwaggerConfig{
+					ParseDirs: []string{"./v1", "./v2"},
+					OutputDir: "swagger",
+					BuildTags: []string{"prod"},
+					Filter:    parser.GinFilter,
+					CallDepth: parser.DefaultCallDepth,
+					DryRun:    true,
+				},
+				Addr: ":8080",
+			},
+			args: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if test.configFile != "" {
+				if err := os.WriteFile(DefaultConfigFile, []byte(test.configFile), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			conf, args, err := parseConfig("init", test.arguments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*conf, test.expected) {
+				t.Errorf("parseConfig = %+v, expected %+v", *conf, test.expected)
+			}
+			if !reflect.DeepEqual(args, test.args) {
+				t.Errorf("parseConfig args = %q, expected %q", args, test.args)
+			}
+		})
+	}
+}
+
+func TestParseConfigMissingFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if _, _, err := parseConfig("init", []string{"-config", "missing.json"}); err == nil {
+		t.Error("parseConfig with a missing config file returns no error")
+	}
+}
+
+func TestSameJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		generated string
+		expected  bool
+	}{
+		{name: "same bytes", current: `{"a":1}`, generated: `{"a":1}`, expected: true},
+		{name: "same content in another format", current: "{\n  \"a\": 1\n}", generated: `{"a":1}`, expected: true},
+		{name: "different content", current: `{"a":1}`, generated: `{"a":2}`, expected: false},
+		{name: "missing", generated: `{"a":1}`, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			fileName, generated := filepath.Join(dir, "swagger.json"), filepath.Join(dir, "generated.json")
+			if test.current != "" {
+				if err := os.WriteFile(fileName, []byte(test.current), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.WriteFile(generated, []byte(test.generated), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			same, err := sameJSON(fileName, generated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if same != test.expected {
+				t.Errorf("sameJSON = %t, expected %t", same, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// swaggerInitializer loads the swagger.json served by serve instead of the petstore of swagger ui.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "doc.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    docExpansion: "list",
+    validatorUrl: null,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+    ],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// runServe serves the swagger ui at /swagger/, swagger.json is read on every request so that it is refreshed by init.
+func runServe(conf *config, _ []string) error {
+	fileName := filepath.Join(conf.OutputDir, "swagger.json")
+	if _, err := os.Stat(fileName); err != nil {
+		log.Printf("[WARNING] %s is not found, run go-swagger init first", fileName)
+	}
+
+	files := swaggerFiles.Handler
+	files.Prefix = "/swagger/"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/swagger/", func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/swagger/") {
+		case "":
+			http.Redirect(w, r, "/swagger/index.html", http.StatusMovedPermanently)
+		case "doc.json":
+			data, err := os.ReadFile(fileName)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = w.Write(data)
+		case "swagger-initializer.js":
+			w.Header().Set("Content-Type", "application/javascript")
+			_, _ = w.Write([]byte(swaggerInitializer))
+		default:
+			files.ServeHTTP(w, r)
+		}
+	})
+
+	host := conf.Addr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	log.Printf("[INFO] serve swagger ui at http://%s/swagger/index.html", host)
+
+	return http.ListenAndServe(conf.Addr, mux)
+}
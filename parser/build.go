@@ -3,6 +3,7 @@ package parser
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 )
 
 type File struct {
@@ -14,15 +15,34 @@ type File struct {
 
 type FunctionDesc struct {
 	source      *ast.FuncDecl
-	fset        token.FileSet
+	fset        *token.FileSet
 	Comments    []string
 	Name        string
 	PackageName string
-	Params      []FuncItem
-	Results     []FuncItem
+	// Filter the handler signature matched by the function
+	Filter  string
+	Params  []FuncItem
+	Results []FuncItem
 
 	Vars  map[string]FuncItem
 	Exprs []ExprItem
+
+	// deps the files of the functions followed from the function
+	deps []string
+	// closure the name of the closure returned by the function if it is a handler factory, e.g. func1
+	closure string
+	// qualifier names the packages of the types as the file of the function refers to them
+	qualifier types.Qualifier
+}
+
+// routeName returns the name matched against the handlers of the routes,
+// e.g. api.Controller.Get, or api.NewHandler.func1 for the closure returned by a handler factory.
+func (desc *FunctionDesc) routeName() string {
+	if desc.closure != "" {
+		return desc.PackageName + "." + desc.closure
+	}
+
+	return desc.PackageName
 }
 
 type FuncItem struct {
@@ -32,14 +52,34 @@ type FuncItem struct {
 
 type ExprItem struct {
 	Receiver string
-	Name     string
-	Args     []ExprArgItem
+	// Target the expression the method is called on, e.g. r.URL.Query()
+	Target string
+	Name   string
+	Args   []ExprArgItem
+	// branch the block ending with a return which contains the call in the handler, e.g. the error branch,
+	// for the calls of the followed functions the block of the call of the function
+	branch ast.Node
 }
 
 type ExprArgItem struct {
 	Name  string
 	Type  string
 	Value string
+	// Values the constant values assigned to a variable argument in the function, e.g. the status set in branches
+	Values []string
+	// Schema the inline schema of a map literal argument, e.g. object{id=integer} for gin.H{"id": 1}
+	Schema string
+	// typ the type of the argument, e.g. the struct bound by ctx.ShouldBindUri(&uri)
+	typ types.Type
+}
+
+// constantValues returns the constant value of the argument, or the constant values assigned to it.
+func (arg ExprArgItem) constantValues() []string {
+	if arg.Value != "" {
+		return []string{arg.Value}
+	}
+
+	return arg.Values
 }
 
 func NewFile(name string, source *ast.File) *File {
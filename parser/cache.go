@@ -0,0 +1,331 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheFileName the name of the file caching the generated annotations in the output dir, the type definitions and
+// schemas are not cached.
+const CacheFileName = ".go-swagger-cache.json"
+
+// cacheVersion changes when the format of the cache or the generated annotations change.
+const cacheVersion = "2"
+
+// generateCache caches the annotations generated for the handlers of the parsed dirs,
+// the dirs whose files are not changed are not loaded and checked again.
+type generateCache struct {
+	// Key the hash of the settings and routes which the annotations are generated with
+	Key string `json:"key"`
+	// Dirs the parsed dirs keyed by absolute path
+	Dirs map[string]*cachedDir `json:"dirs"`
+	// Files the go files and the files they depend on keyed by absolute path
+	Files map[string]*cachedFile `json:"files"`
+}
+
+type cachedDir struct {
+	PkgPath string `json:"pkgPath"`
+	// Files the go files in the dir
+	Files []string `json:"files"`
+	// Compiled the files of the package loaded with the build tags
+	Compiled []string `json:"compiled"`
+}
+
+type cachedFile struct {
+	ModTime time.Time `json:"modTime"`
+	Size    int64     `json:"size"`
+	Hash    string    `json:"hash"`
+	// Comments the generated comments keyed by the handler, e.g. api.Controller.Get
+	Comments map[string][]string `json:"comments,omitempty"`
+	// Deps the files of the functions followed from the handlers
+	Deps []string `json:"deps,omitempty"`
+}
+
+func newGenerateCache(key string) *generateCache {
+	return &generateCache{
+		Key:   key,
+		Dirs:  make(map[string]*cachedDir),
+		Files: make(map[string]*cachedFile),
+	}
+}
+
+// loadGenerateCache reads the cache in outputDir, an empty cache is returned if it does not exist or is broken.
+func loadGenerateCache(outputDir string) *generateCache {
+	cache := newGenerateCache("")
+
+	bytes, err := os.ReadFile(filepath.Join(outputDir, CacheFileName))
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(bytes, cache); err != nil {
+		log.Printf("[WARNING] ignore the broken cache %s, error: %s", CacheFileName, err)
+		return newGenerateCache("")
+	}
+
+	return cache
+}
+
+// generateCacheKey hashes everything the generated annotations depend on besides the source files.
+func generateCacheKey(routeInfos map[string][]RouteInfo, filters []string, callDepth int, buildTags []string) string {
+	routes := flattenRoutes(routeInfos)
+
+	bytes, _ := json.Marshal(struct {
+		Version   string
+		Routes    []RouteInfo
+		Filters   []string
+		CallDepth int
+		BuildTags []string
+	}{cacheVersion, routes, filters, callDepth, buildTags})
+	sum := sha256.Sum256(bytes)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (cache *generateCache) save(outputDir string) error {
+	bytes, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, CacheFileName), bytes, os.ModePerm)
+}
+
+// scan records the state of the files in dirs, and reports the dirs need to be generated again,
+// the annotations of the other dirs are taken from previous.
+func (cache *generateCache) scan(previous *generateCache, dirs []string) ([]bool, error) {
+	dirty := make([]bool, len(dirs))
+
+	for index, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		files, err := goFiles(absDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range files {
+			if _, err := cache.state(path, previous.Files[path]); err != nil {
+				return nil, err
+			}
+		}
+
+		prevDir := previous.Dirs[absDir]
+		if previous.Key != cache.Key || prevDir == nil || strings.Join(prevDir.Files, ",") != strings.Join(files, ",") {
+			dirty[index] = true
+			cache.Dirs[absDir] = &cachedDir{Files: files}
+			continue
+		}
+
+		dirty[index] = cache.filesChanged(previous, files)
+		if dirty[index] {
+			cache.Dirs[absDir] = &cachedDir{Files: files}
+			continue
+		}
+
+		cache.Dirs[absDir] = prevDir
+		for _, path := range files {
+			cache.Files[path].Comments = previous.Files[path].Comments
+			cache.Files[path].Deps = previous.Files[path].Deps
+		}
+	}
+
+	return dirty, nil
+}
+
+// state returns the current state of a file, the file is hashed only if its size or modification time changed.
+func (cache *generateCache) state(path string, previous *cachedFile) (*cachedFile, error) {
+	if state, exist := cache.Files[path]; exist {
+		return state, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &cachedFile{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+	}
+	if previous != nil && previous.Size == state.Size && previous.ModTime.Equal(state.ModTime) {
+		state.Hash = previous.Hash
+	} else {
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(bytes)
+		state.Hash = hex.EncodeToString(sum[:])
+	}
+	cache.Files[path] = state
+
+	return state, nil
+}
+
+// changed reports whether a file is changed or removed since previous.
+func (cache *generateCache) changed(previous *generateCache, path string) bool {
+	prevState := previous.Files[path]
+	state, err := cache.state(path, prevState)
+	if err != nil {
+		return true
+	}
+
+	return prevState == nil || prevState.Hash != state.Hash
+}
+
+// filesChanged reports whether any of the files or the files they depend on is changed since previous.
+func (cache *generateCache) filesChanged(previous *generateCache, files []string) bool {
+	for _, path := range files {
+		if cache.changed(previous, path) {
+			return true
+		}
+		for _, dep := range previous.Files[path].Deps {
+			if cache.changed(previous, dep) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// record records the annotations generated for the handlers of a file.
+func (cache *generateCache) record(path string, functions []FunctionDesc) {
+	state, err := cache.state(path, nil)
+	if err != nil {
+		log.Printf("[WARNING] failed to cache file %s, error: %s", path, err)
+		return
+	}
+
+	state.Comments = generatedComments(functions)
+	deps := make(map[string]bool)
+	for index := range functions {
+		for _, dep := range functions[index].deps {
+			deps[dep] = true
+		}
+	}
+
+	state.Deps = make([]string, 0, len(deps))
+	for dep := range deps {
+		if _, err := cache.state(dep, nil); err != nil {
+			continue
+		}
+		state.Deps = append(state.Deps, dep)
+	}
+	sort.Strings(state.Deps)
+}
+
+// refresh updates the state of a file written by the generator, the cached comments are kept.
+func (cache *generateCache) refresh(path string) {
+	previous, exist := cache.Files[path]
+	if !exist {
+		return
+	}
+	delete(cache.Files, path)
+
+	state, err := cache.state(path, nil)
+	if err != nil {
+		return
+	}
+	state.Comments = previous.Comments
+	state.Deps = previous.Deps
+}
+
+// recordDir records the package and the compiled files of a dir.
+func (cache *generateCache) recordDir(dir, pkgPath string, compiled []string) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+	cached, exist := cache.Dirs[absDir]
+	if !exist {
+		cached = &cachedDir{}
+		cache.Dirs[absDir] = cached
+	}
+	cached.PkgPath = pkgPath
+	cached.Compiled = compiled
+}
+
+// restore parses the compiled files of a dir and sets the cached comments to their handlers.
+func (cache *generateCache) restore(fileSet *token.FileSet, dir string) (*cachedDir, []*ast.File, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	cached := cache.Dirs[absDir]
+
+	trees := make([]*ast.File, 0, len(cached.Compiled))
+	for _, path := range cached.Compiled {
+		tree, err := goparser.ParseFile(fileSet, path, nil, goparser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		state := cache.Files[path]
+		restored := make(map[string]bool, len(state.Comments))
+		for _, declaration := range tree.Decls {
+			decl, ok := declaration.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			name := funcPackageName(tree, decl)
+			comments, exist := state.Comments[name]
+			if !exist {
+				continue
+			}
+			restored[name] = true
+			decl.Doc = newCommentGroup(comments)
+			tree.Comments = append(tree.Comments, decl.Doc)
+		}
+		// the comments of the inline closures are attached to the declarations added by parseFileAST
+		names := make([]string, 0)
+		for name := range state.Comments {
+			if !restored[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			decl := &ast.FuncDecl{
+				Doc:  newCommentGroup(state.Comments[name]),
+				Name: ast.NewIdent(strings.TrimPrefix(name, tree.Name.Name+".")),
+				Type: &ast.FuncType{},
+			}
+			tree.Decls = append(tree.Decls, decl)
+			tree.Comments = append(tree.Comments, decl.Doc)
+		}
+		trees = append(trees, tree)
+	}
+
+	return cached, trees, nil
+}
+
+// goFiles lists the go files in dir except the tests.
+func goFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	return files, nil
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateCacheScan(t *testing.T) {
+	comments := map[string][]string{"api.GetUser": {"// @Summary GetUser"}}
+
+	tests := []struct {
+		name     string
+		key      string
+		change   func(t *testing.T, dir, depDir string)
+		expected bool
+	}{
+		{
+			name:     "unchanged",
+			key:      "key",
+			change:   func(t *testing.T, dir, depDir string) {},
+			expected: false,
+		},
+		{
+			name:     "settings changed",
+			key:      "other",
+			change:   func(t *testing.T, dir, depDir string) {},
+			expected: true,
+		},
+		{
+			name: "file changed",
+			key:  "key",
+			change: func(t *testing.T, dir, depDir string) {
+				writeFile(t, filepath.Join(dir, "api.go"), "package api\n\nfunc GetUser() { Find() }\n")
+			},
+			expected: true,
+		},
+		{
+			name: "dependency changed",
+			key:  "key",
+			change: func(t *testing.T, dir, depDir string) {
+				writeFile(t, filepath.Join(depDir, "store.go"), "package store\n\nfunc Find() int { return 1 }\n")
+			},
+			expected: true,
+		},
+		{
+			name: "file added",
+			key:  "key",
+			change: func(t *testing.T, dir, depDir string) {
+				writeFile(t, filepath.Join(dir, "user.go"), "package api\n")
+			},
+			expected: true,
+		},
+		{
+			name: "test file added",
+			key:  "key",
+			change: func(t *testing.T, dir, depDir string) {
+				writeFile(t, filepath.Join(dir, "api_test.go"), "package api\n")
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, depDir := t.TempDir(), t.TempDir()
+			path, depPath := filepath.Join(dir, "api.go"), filepath.Join(depDir, "store.go")
+			writeFile(t, path, "package api\n\nfunc GetUser() {}\n")
+			writeFile(t, depPath, "package store\n\nfunc Find() {}\n")
+
+			previous := newGenerateCache("key")
+			if _, err := previous.scan(newGenerateCache(""), []string{dir}); err != nil {
+				t.Fatal(err)
+			}
+			previous.Files[path].Comments = comments
+			previous.Files[path].Deps = []string{depPath}
+			if _, err := previous.state(depPath, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			test.change(t, dir, depDir)
+			cache := newGenerateCache(test.key)
+			dirty, err := cache.scan(previous, []string{dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dirty[0] != test.expected {
+				t.Errorf("scan reports dirty %t, expected %t", dirty[0], test.expected)
+			}
+			if !dirty[0] && !reflect.DeepEqual(cache.Files[path].Comments, comments) {
+				t.Errorf("cached comments = %q, expected %q", cache.Files[path].Comments, comments)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
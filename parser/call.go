@@ -0,0 +1,486 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// DefaultCallDepth the default depth of the calls followed from a handler.
+const DefaultCallDepth = 3
+
+// funcSource a function declaration, the file declaring it and the type info of its package.
+type funcSource struct {
+	decl *ast.FuncDecl
+	path string
+	info *types.Info
+}
+
+// funcIndex the declared functions keyed by types.Func.FullName, e.g. pkg.respondError or (*pkg.Handler).respond.
+type funcIndex map[string]funcSource
+
+func (index funcIndex) add(fileSet *token.FileSet, file *ast.File, info *types.Info) {
+	path := fileSet.Position(file.Package).Filename
+	for _, declaration := range file.Decls {
+		decl, ok := declaration.(*ast.FuncDecl)
+		if !ok || decl.Body == nil {
+			continue
+		}
+		fn, ok := info.Defs[decl.Name].(*types.Func)
+		if !ok {
+			continue
+		}
+		index[fn.FullName()] = funcSource{decl: decl, path: path, info: info}
+	}
+}
+
+// addPackages adds the functions of pkgs and their dependencies in the same modules.
+func (index funcIndex) addPackages(fileSet *token.FileSet, pkgs []*packages.Package) {
+	roots := make(map[*packages.Package]bool, len(pkgs))
+	modules := make(map[string]bool)
+	for _, pkg := range pkgs {
+		roots[pkg] = true
+		if pkg.Module != nil {
+			modules[pkg.Module.Path] = true
+		}
+	}
+
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.TypesInfo == nil {
+			return
+		}
+		if !roots[pkg] && (pkg.Module == nil || !modules[pkg.Module.Path]) {
+			return
+		}
+		for _, file := range pkg.Syntax {
+			index.add(fileSet, file, pkg.TypesInfo)
+		}
+	})
+}
+
+// funcLit a closure and its name given by the compiler, e.g. func1, or func1.1 for a closure in func1.
+type funcLit struct {
+	lit  *ast.FuncLit
+	name string
+}
+
+// funcLits returns the closures in the body of a function in the order of the source.
+func funcLits(body ast.Node) []funcLit {
+	lits := make([]funcLit, 0)
+	nameFuncLits(body, "func", &lits)
+
+	return lits
+}
+
+func nameFuncLits(node ast.Node, prefix string, lits *[]funcLit) {
+	count := 0
+	ast.Inspect(node, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		count++
+		name := fmt.Sprintf("%s%d", prefix, count)
+		*lits = append(*lits, funcLit{lit: lit, name: name})
+		nameFuncLits(lit.Body, name+".", lits)
+		return false
+	})
+}
+
+// returnedFuncLit returns the first closure returned by a function, e.g. return func(ctx *gin.Context) {...}
+// or return gin.HandlerFunc(func(ctx *gin.Context) {...}), nil if the function returns no closure.
+func returnedFuncLit(body *ast.BlockStmt) *ast.FuncLit {
+	var returned *ast.FuncLit
+	ast.Inspect(body, func(n ast.Node) bool {
+		if returned != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// the returns of the closure
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) != 1 {
+				return true
+			}
+			result := unparen(node.Results[0])
+			if call, ok := result.(*ast.CallExpr); ok && len(call.Args) == 1 {
+				result = unparen(call.Args[0])
+			}
+			returned, _ = result.(*ast.FuncLit)
+		}
+		return true
+	})
+
+	return returned
+}
+
+// exprCollector collects the calls of a handler, following the calls into the indexed functions
+// which receive one of the handler's params, e.g. respondError(ctx, err).
+type exprCollector struct {
+	index    funcIndex
+	maxDepth int
+	// the types of the handler's params, e.g. *github.com/gin-gonic/gin.Context
+	paramTypes map[string]bool
+	// the functions being followed, to break recursive calls
+	visiting map[string]bool
+	// the files of the followed functions
+	deps map[string]bool
+	// qualifier names the packages of the types as the file of the handler refers to them
+	qualifier types.Qualifier
+	// branch the branch of the handler's call being followed
+	branch ast.Node
+}
+
+func newExprCollector(index funcIndex, maxDepth int, params []FuncItem, qualifier types.Qualifier) *exprCollector {
+	paramTypes := make(map[string]bool, len(params))
+	for _, param := range params {
+		paramTypes[param.Type] = true
+	}
+
+	return &exprCollector{
+		index:      index,
+		maxDepth:   maxDepth,
+		paramTypes: paramTypes,
+		visiting:   make(map[string]bool),
+		deps:       make(map[string]bool),
+		qualifier:  qualifier,
+	}
+}
+
+// dependencies returns the sorted files of the followed functions.
+func (c *exprCollector) dependencies() []string {
+	deps := make([]string, 0, len(c.deps))
+	for path := range c.deps {
+		deps = append(deps, path)
+	}
+	sort.Strings(deps)
+
+	return deps
+}
+
+// collect appends the calls in body to desc, bindings maps the params of a followed function to the arguments passed by its caller.
+func (c *exprCollector) collect(desc *FunctionDesc, body ast.Node, info *types.Info, depth int, bindings map[types.Object]ExprArgItem) {
+	assigned := constantAssignments(body, info)
+
+	// the nodes enclosing the current one
+	var stack []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		switch node := n.(type) {
+		// 获取函数体变量
+		case *ast.Ident:
+			if depth == 0 && info.Defs[node] != nil {
+				desc.Vars[node.Name] = FuncItem{
+					Name: node.Name,
+					Type: info.Defs[node].Type().String(),
+				}
+			}
+		// 获取函数内函数调用
+		case *ast.CallExpr:
+			if depth == 0 {
+				c.branch = returningBlock(stack)
+			}
+			c.follow(desc, node, info, depth, bindings, assigned)
+
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			var receiver string
+			if selectorType, exist := info.Selections[selector]; exist {
+				if selectorType.Kind() != types.MethodVal {
+					return true
+				}
+				receiver = selectorType.Recv().String()
+			} else if ident, ok := selector.X.(*ast.Ident); ok {
+				// package function, e.g. http.Error
+				pkgName, ok := info.Uses[ident].(*types.PkgName)
+				if !ok {
+					return true
+				}
+				receiver = pkgName.Imported().Path()
+			} else {
+				return true
+			}
+
+			desc.Exprs = append(desc.Exprs, ExprItem{
+				Receiver: receiver,
+				Target:   ExprString(selector.X),
+				Name:     selector.Sel.Name,
+				Args:     c.exprArgs(node.Args, info, bindings, assigned),
+				branch:   c.branch,
+			})
+		}
+
+		return true
+	})
+}
+
+// returningBlock returns the innermost block of stack which ends with a return, nil if there is none.
+func returningBlock(stack []ast.Node) ast.Node {
+	for index := len(stack) - 1; index >= 0; index-- {
+		block, ok := stack[index].(*ast.BlockStmt)
+		if !ok || len(block.List) == 0 {
+			continue
+		}
+		if _, ok := block.List[len(block.List)-1].(*ast.ReturnStmt); ok {
+			return block
+		}
+	}
+
+	return nil
+}
+
+// follow collects the calls of the callee as the handler's own when it is passed one of the handler's params.
+func (c *exprCollector) follow(desc *FunctionDesc, call *ast.CallExpr, info *types.Info, depth int, bindings map[types.Object]ExprArgItem, assigned *assignments) {
+	if depth >= c.maxDepth {
+		return
+	}
+
+	fn := calleeFunc(call, info)
+	if fn == nil {
+		return
+	}
+	name := fn.FullName()
+	source, exist := c.index[name]
+	if !exist || c.visiting[name] {
+		return
+	}
+
+	passed := false
+	for _, arg := range call.Args {
+		if argType, exist := info.Types[arg]; exist && c.paramTypes[argType.Type.String()] {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		return
+	}
+
+	// bind the callee's params to the arguments, so that ShouldBindJSON(v) is attributed the type of the caller's value
+	calleeBindings := make(map[types.Object]ExprArgItem)
+	index := 0
+	for _, field := range source.decl.Type.Params.List {
+		for _, param := range field.Names {
+			if index >= len(call.Args) {
+				break
+			}
+			if obj := source.info.Defs[param]; obj != nil {
+				if arg, ok := c.exprArg(call.Args[index], info, bindings, assigned); ok {
+					calleeBindings[obj] = arg
+				}
+			}
+			index++
+		}
+	}
+
+	c.deps[source.path] = true
+	c.visiting[name] = true
+	c.collect(desc, source.decl.Body, source.info, depth+1, calleeBindings)
+	delete(c.visiting, name)
+}
+
+func calleeFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		fn, _ := info.Uses[fun].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		if selection, exist := info.Selections[fun]; exist {
+			fn, _ := selection.Obj().(*types.Func)
+			return fn
+		}
+		fn, _ := info.Uses[fun.Sel].(*types.Func)
+		return fn
+	}
+
+	return nil
+}
+
+// assignments the values assigned to the variables of a function.
+type assignments struct {
+	// constants the constant values, e.g. 200 and 201 for code := http.StatusOK; if created { code = http.StatusCreated }
+	constants map[types.Object][]string
+	// literals the last map literal, e.g. resp := gin.H{"id": id}
+	literals map[types.Object]*ast.CompositeLit
+}
+
+// constantAssignments returns the constant values and the map literals assigned to the variables in body.
+func constantAssignments(body ast.Node, info *types.Info) *assignments {
+	assigned := &assignments{
+		constants: make(map[types.Object][]string),
+		literals:  make(map[types.Object]*ast.CompositeLit),
+	}
+	assign := func(lhs *ast.Ident, rhs ast.Expr) {
+		obj := info.Defs[lhs]
+		if obj == nil {
+			obj = info.Uses[lhs]
+		}
+		if _, ok := obj.(*types.Var); !ok {
+			return
+		}
+		if literal := mapLiteral(rhs, info); literal != nil {
+			assigned.literals[obj] = literal
+			return
+		}
+		value, exist := info.Types[rhs]
+		if !exist || value.Value == nil {
+			return
+		}
+		if exact := value.Value.ExactString(); !containsString(assigned.constants[obj], exact) {
+			assigned.constants[obj] = append(assigned.constants[obj], exact)
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					assign(ident, node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) != len(node.Values) {
+				return true
+			}
+			for i, name := range node.Names {
+				assign(name, node.Values[i])
+			}
+		}
+		return true
+	})
+
+	return assigned
+}
+
+func (c *exprCollector) exprArgs(exprs []ast.Expr, info *types.Info, bindings map[types.Object]ExprArgItem, assigned *assignments) []ExprArgItem {
+	args := make([]ExprArgItem, 0)
+
+	for _, argEntry := range exprs {
+		if arg, ok := c.exprArg(argEntry, info, bindings, assigned); ok {
+			args = append(args, arg)
+		}
+	}
+
+	return args
+}
+
+func (c *exprCollector) exprArg(expr ast.Expr, info *types.Info, bindings map[types.Object]ExprArgItem, assigned *assignments) (ExprArgItem, bool) {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if arg, exist := bindings[info.Uses[ident]]; exist {
+			return arg, true
+		}
+	}
+
+	argType, exist := info.Types[expr]
+	if !exist {
+		return ExprArgItem{}, false
+	}
+
+	var value string
+	var values []string
+	literal := mapLiteral(expr, info)
+	if argType.Value != nil {
+		value = argType.Value.ExactString()
+	} else if ident, ok := expr.(*ast.Ident); ok && assigned != nil {
+		values = assigned.constants[info.Uses[ident]]
+		literal = assigned.literals[info.Uses[ident]]
+	}
+
+	var schema string
+	if literal != nil {
+		schema = literalSchema(literal, info, c.qualifier)
+	}
+
+	return ExprArgItem{
+		Type:   argType.Type.String(),
+		Name:   ExprString(expr),
+		Value:  value,
+		Values: values,
+		Schema: schema,
+		typ:    argType.Type,
+	}, true
+}
+
+// mapLiteral returns the non-empty map literal of expr whose keys are all constant strings, e.g. gin.H{"id": id}.
+func mapLiteral(expr ast.Expr, info *types.Info) *ast.CompositeLit {
+	if unary, ok := unparen(expr).(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	literal, ok := unparen(expr).(*ast.CompositeLit)
+	if !ok || len(literal.Elts) == 0 {
+		return nil
+	}
+	literalType, exist := info.Types[literal]
+	if !exist {
+		return nil
+	}
+	mapType, ok := literalType.Type.Underlying().(*types.Map)
+	if !ok {
+		return nil
+	}
+	if key, ok := mapType.Key().Underlying().(*types.Basic); !ok || key.Info()&types.IsString == 0 {
+		return nil
+	}
+	for _, elt := range literal.Elts {
+		pair, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil
+		}
+		if key, exist := info.Types[pair.Key]; !exist || key.Value == nil {
+			return nil
+		}
+	}
+
+	return literal
+}
+
+// literalSchemaKeyRegExp the keys which can be written in an inline schema.
+var literalSchemaKeyRegExp = regexp.MustCompile(`^[\w\-\.]+$`)
+
+// literalSchema returns the inline schema of a map literal, the properties take the types of the values,
+// e.g. object{id=integer,items=[]api.Item} for gin.H{"id": 1, "items": items}.
+func literalSchema(literal *ast.CompositeLit, info *types.Info, qualifier types.Qualifier) string {
+	props := make([]string, 0, len(literal.Elts))
+	for _, elt := range literal.Elts {
+		pair := elt.(*ast.KeyValueExpr)
+		key := constant.StringVal(info.Types[pair.Key].Value)
+		if !literalSchemaKeyRegExp.MatchString(key) {
+			continue
+		}
+		var propType string
+		if nested := mapLiteral(pair.Value, info); nested != nil {
+			propType = literalSchema(nested, info, qualifier)
+		} else if value, exist := info.Types[pair.Value]; exist {
+			propType = schemaTypeName(value.Type, qualifier)
+		} else {
+			propType = OBJECT
+		}
+		props = append(props, key+"="+propType)
+	}
+	if len(props) == 0 {
+		return OBJECT
+	}
+	sort.Strings(props)
+
+	return OBJECT + "{" + strings.Join(props, ",") + "}"
+}
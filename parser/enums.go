@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	// EnumVarNamesExtension the names of the constants of the enum values.
+	EnumVarNamesExtension = "x-enum-varnames"
+	// EnumDescriptionsExtension the comments of the constants of the enum values.
+	EnumDescriptionsExtension = "x-enum-descriptions"
+)
+
+// EnumValue a constant of a named type, e.g. StatusActive of const StatusActive Status = "active".
+type EnumValue struct {
+	// Name the name of the constant
+	Name string
+	// Value the value of the constant
+	Value constant.Value
+	// Comment the comment of the constant
+	Comment string
+}
+
+// enumValues returns the values of the constants of a type, the constants of the package of the type are
+// collected when the first type of the package is looked up.
+func (pkgs *PackagesDefinitions) enumValues(typeSpecDef *TypeSpecDef) []EnumValue {
+	if pkgs.loader == nil {
+		return nil
+	}
+	pkg := pkgs.loader.typed(typeSpecDef.PkgPath)
+	if pkg == nil {
+		return nil
+	}
+	if pkgs.enums == nil {
+		pkgs.enums = make(map[string][]EnumValue)
+	}
+	if pkgs.enumPackages == nil {
+		pkgs.enumPackages = make(map[string]bool)
+	}
+	if !pkgs.enumPackages[pkg.PkgPath] {
+		pkgs.enumPackages[pkg.PkgPath] = true
+		pkgs.collectEnums(pkg)
+	}
+
+	return pkgs.enums[pkg.PkgPath+"."+typeSpecDef.Name()]
+}
+
+// collectEnums collects the constants of the named types declared in a package, in the order they are declared,
+// with the exact values of the type info, e.g.
+//
+//	const (
+//		KindUser  Kind = base + iota // a user
+//		KindGroup                    // a group
+//	)
+func (pkgs *PackagesDefinitions) collectEnums(pkg *packages.Package) {
+	for _, astFile := range pkg.Syntax {
+		for _, decl := range astFile.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, astSpec := range genDecl.Specs {
+				valueSpec, ok := astSpec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for _, name := range valueSpec.Names {
+					obj, ok := pkg.TypesInfo.Defs[name].(*types.Const)
+					if !ok || name.Name == "_" {
+						continue
+					}
+					// the constants of the types declared in the package
+					named, ok := types.Unalias(obj.Type()).(*types.Named)
+					if !ok || named.Obj().Pkg() != obj.Pkg() {
+						continue
+					}
+					key := pkg.PkgPath + "." + named.Obj().Name()
+					pkgs.enums[key] = append(pkgs.enums[key], EnumValue{
+						Name:    name.Name,
+						Value:   obj.Val(),
+						Comment: constComment(valueSpec, name.Name),
+					})
+				}
+			}
+		}
+	}
+}
+
+// setEnums sets the values of the constants of a type as the enum of its schema, the constants whose values
+// are not of the type of the schema or repeat the former values are ignored, e.g. the integers of a type which is marshaled as a string.
+func setEnums(schema *spec.Schema, enums []EnumValue) {
+	if len(schema.Type) != 1 {
+		return
+	}
+
+	var (
+		values       []interface{}
+		names        []string
+		descriptions []string
+		commented    bool
+	)
+	for _, enum := range enums {
+		value := enumValue(schema.Type[0], enum.Value)
+		// the values are unique, e.g. const LevelDefault = LevelUnknown
+		if value == nil || containsValue(values, value) {
+			continue
+		}
+		values = append(values, value)
+		names = append(names, enum.Name)
+		descriptions = append(descriptions, enum.Comment)
+		commented = commented || enum.Comment != ""
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	schema.Enum = values
+	schema.AddExtension(EnumVarNamesExtension, names)
+	if commented {
+		schema.AddExtension(EnumDescriptionsExtension, descriptions)
+	}
+}
+
+// enumValue converts a constant to a value of schemaType, nil if it is not of the type.
+func enumValue(schemaType string, value constant.Value) interface{} {
+	switch {
+	case schemaType == STRING && value.Kind() == constant.String:
+		return constant.StringVal(value)
+	case schemaType == BOOLEAN && value.Kind() == constant.Bool:
+		return constant.BoolVal(value)
+	case schemaType == INTEGER && value.Kind() == constant.Int:
+		if intValue, exact := constant.Int64Val(value); exact {
+			return intValue
+		}
+	case schemaType == NUMBER && (value.Kind() == constant.Int || value.Kind() == constant.Float):
+		floatValue, _ := constant.Float64Val(value)
+		return floatValue
+	}
+
+	return nil
+}
+
+// containsValue reports whether values contains value.
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// constComment returns the comment of a constant, the doc above it without the name if there is no comment after it.
+func constComment(valueSpec *ast.ValueSpec, name string) string {
+	comment := strings.TrimSpace(valueSpec.Comment.Text())
+	if comment == "" {
+		comment = strings.TrimSpace(valueSpec.Doc.Text())
+		if rest, found := strings.CutPrefix(comment, name+" "); found {
+			comment = strings.TrimSpace(rest)
+		}
+	}
+
+	return strings.Join(strings.Fields(comment), " ")
+}
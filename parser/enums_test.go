@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumSchema(t *testing.T) {
+	schemas := parseTypeSchemas(t, "testdata/enums", "Status", "Kind", "Flag", "Level", "Task")
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "Status",
+			expected: `{"type":"string","enum":["active","banned"],"x-enum-descriptions":["正常","已封禁"],"x-enum-varnames":["StatusActive","StatusBanned"]}`,
+		},
+		{
+			name:     "Kind",
+			expected: `{"type":"integer","enum":[10,11,13,14],"x-enum-descriptions":["a user","a group","",""],"x-enum-varnames":["KindUser","KindGroup","KindRobot","KindAdmin"]}`,
+		},
+		{
+			name:     "Flag",
+			expected: `{"type":"integer","enum":[1,2,4],"x-enum-varnames":["FlagRead","FlagWrite","FlagExec"]}`,
+		},
+		{
+			name:     "Level",
+			expected: `{"type":"string","enum":["unknown"],"x-enum-varnames":["LevelUnknown"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(schemas[test.name])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.expected {
+				t.Errorf("schema of %s = %s, expected %s", test.name, got, test.expected)
+			}
+		})
+	}
+
+	// the enums tag of a field takes precedence over the constants
+	got, err := json.Marshal(schemas["Task"].Properties["kind"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"type":"integer","enum":[10,11]}`; string(got) != expected {
+		t.Errorf("schema of Task.kind = %s, expected %s", got, expected)
+	}
+}
@@ -0,0 +1,236 @@
+package parser
+
+import (
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// maxDefinedDepth the max depth of the types defined by the other types, e.g. type A B.
+const maxDefinedDepth = 8
+
+// structProperty a property of a struct, which is a field of the struct or promoted by its embedded structs.
+type structProperty struct {
+	name     string
+	schema   spec.Schema
+	required bool
+	// depth the depth of the embedded struct declaring the field, 0 for the fields of the struct itself
+	depth int
+	// tagged whether the name is given by the json tag
+	tagged bool
+}
+
+// structProperties returns the properties of the fields of a struct, the fields of the embedded structs are
+// promoted as encoding/json does, the fields promoted through the embedded pointers are not required since they
+// are omitted by the nil pointers, visited the structs being flattened.
+func (parser *Parser) structProperties(file *ast.File, fields *ast.FieldList, depth int, optional bool, visited map[*TypeSpecDef]bool) ([]structProperty, error) {
+	props := make([]structProperty, 0, len(fields.List))
+	for _, field := range fields.List {
+		if isFlattened(field) {
+			promoted, ok, err := parser.embeddedProperties(file, field, depth, optional, visited)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				props = append(props, promoted...)
+				continue
+			}
+			// the embedded types which are not structs are named by their types
+		}
+
+		tagName, _ := jsonTag(field)
+		for _, named := range splitNames(field) {
+			fieldProps, required, err := parser.parseStructField(file, named)
+			if err != nil {
+				if err == ErrFuncTypeField {
+					continue
+				}
+
+				return nil, err
+			}
+			for name, schema := range fieldProps {
+				props = append(props, structProperty{
+					name:     name,
+					schema:   schema,
+					required: !optional && findInSlice(required, name),
+					depth:    depth,
+					tagged:   tagName != "",
+				})
+			}
+		}
+	}
+
+	return props, nil
+}
+
+// embeddedProperties returns the properties promoted by an embedded struct, ok is false if the field is not a struct.
+func (parser *Parser) embeddedProperties(file *ast.File, field *ast.Field, depth int, optional bool, visited map[*TypeSpecDef]bool) ([]structProperty, bool, error) {
+	typeName, err := getFieldType(field.Type)
+	if err != nil {
+		return nil, false, nil
+	}
+	// the types described by their own schemas, e.g. time.Time or the marshalers, are not flattened
+	if parser.wellKnownSchema(typeName, file) != nil {
+		return nil, false, nil
+	}
+	typeSpecDef := parser.packages.FindTypeSpec(typeName, file, parser.ParseDependency)
+	// the types defined by the other structs, e.g. type Base models.Base
+	for level := 0; typeSpecDef != nil && level < maxDefinedDepth; level++ {
+		if _, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType); ok {
+			break
+		}
+		underlying, err := getFieldType(typeSpecDef.TypeSpec.Type)
+		if err != nil {
+			return nil, false, nil
+		}
+		typeSpecDef = parser.packages.FindTypeSpec(underlying, typeSpecDef.File, parser.ParseDependency)
+	}
+	if typeSpecDef == nil || parser.packages.hasCustomSchema(typeSpecDef) {
+		return nil, false, nil
+	}
+	structType, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType)
+	if !ok || structType.Fields == nil {
+		return nil, false, nil
+	}
+	if visited[typeSpecDef] {
+		return nil, true, nil
+	}
+
+	visited[typeSpecDef] = true
+	defer delete(visited, typeSpecDef)
+	_, pointer := field.Type.(*ast.StarExpr)
+	props, err := parser.structProperties(typeSpecDef.File, structType.Fields, depth+1, optional || pointer, visited)
+
+	return props, true, err
+}
+
+// dominantProperties drops the properties hidden by encoding/json: of the properties of the same name, the ones
+// of the least depth are kept, of which the only one or the only tagged one is the property, otherwise they
+// conflict and are all dropped.
+func dominantProperties(props []structProperty) []structProperty {
+	names := make([]string, 0, len(props))
+	byName := make(map[string][]structProperty, len(props))
+	for _, prop := range props {
+		if _, exist := byName[prop.name]; !exist {
+			names = append(names, prop.name)
+		}
+		byName[prop.name] = append(byName[prop.name], prop)
+	}
+
+	dominants := make([]structProperty, 0, len(names))
+	for _, name := range names {
+		candidates := byName[name]
+		depth := candidates[0].depth
+		for _, prop := range candidates {
+			if prop.depth < depth {
+				depth = prop.depth
+			}
+		}
+
+		var shallowest, tagged []structProperty
+		for _, prop := range candidates {
+			if prop.depth != depth {
+				continue
+			}
+			shallowest = append(shallowest, prop)
+			if prop.tagged {
+				tagged = append(tagged, prop)
+			}
+		}
+		switch {
+		case len(shallowest) == 1:
+			dominants = append(dominants, shallowest[0])
+		case len(tagged) == 1:
+			dominants = append(dominants, tagged[0])
+		}
+	}
+
+	return dominants
+}
+
+// isFlattened reports whether the fields of a field are promoted, which is embedded without a json name or inline,
+// e.g. json:",inline".
+func isFlattened(field *ast.Field) bool {
+	name, options := jsonTag(field)
+	if name == "-" && options == "" || hasSwaggerIgnore(field) {
+		return false
+	}
+
+	return len(field.Names) == 0 && name == "" || hasOption(options, "inline")
+}
+
+// jsonTag returns the name and the options of the json tag of a field, e.g. id and omitempty of json:"id,omitempty".
+func jsonTag(field *ast.Field) (string, string) {
+	if field.Tag == nil {
+		return "", ""
+	}
+	tag := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", "")).Get("json")
+	name, options, _ := strings.Cut(tag, ",")
+
+	return strings.TrimSpace(name), options
+}
+
+func hasJSONOption(field *ast.Field, option string) bool {
+	_, options := jsonTag(field)
+
+	return hasOption(options, option)
+}
+
+func hasOption(options, option string) bool {
+	for _, item := range strings.Split(options, ",") {
+		if strings.TrimSpace(item) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasSwaggerIgnore(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+
+	return strings.EqualFold(reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", "")).Get("swaggerignore"), "true")
+}
+
+// splitNames splits a field declaring several names into the fields of each name, e.g. X and Y of X, Y int.
+func splitNames(field *ast.Field) []*ast.Field {
+	if len(field.Names) <= 1 {
+		return []*ast.Field{field}
+	}
+
+	fields := make([]*ast.Field, len(field.Names))
+	for index, name := range field.Names {
+		fields[index] = &ast.Field{Doc: field.Doc, Names: []*ast.Ident{name}, Type: field.Type, Tag: field.Tag, Comment: field.Comment}
+	}
+
+	return fields
+}
+
+// goFieldName returns the name of a field, the name of its type if it is embedded, e.g. Base of *models.Base.
+func goFieldName(field *ast.Field) string {
+	if len(field.Names) > 0 {
+		return field.Names[0].Name
+	}
+
+	expr := field.Type
+	for {
+		switch typeExpr := expr.(type) {
+		case *ast.StarExpr:
+			expr = typeExpr.X
+		case *ast.IndexExpr:
+			expr = typeExpr.X
+		case *ast.IndexListExpr:
+			expr = typeExpr.X
+		case *ast.SelectorExpr:
+			return typeExpr.Sel.Name
+		case *ast.Ident:
+			return typeExpr.Name
+		default:
+			return ""
+		}
+	}
+}
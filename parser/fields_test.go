@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestStructProperties(t *testing.T) {
+	tests := []struct {
+		name              string
+		requiredByDefault bool
+		required          []string
+	}{
+		{name: "required by tags", required: []string{"code"}},
+		{name: "required by default", requiredByDefault: true, required: []string{"-", "code", "creator", "id", "meta", "version"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, file := newTypesParser(t, "testdata/fields")
+			parser.RequiredByDefault = test.requiredByDefault
+			schema, err := parser.getTypeSchema("fields.User", file, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Name of Base and Audit conflict, Password is ignored, internal is unexported
+			names := make([]string, 0, len(schema.Properties))
+			for name := range schema.Properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if expected := []string{"-", "code", "createdAt", "creator", "email", "id", "meta", "nickname", "version"}; !reflect.DeepEqual(names, expected) {
+				t.Errorf("properties of fields.User = %q, expected %q", names, expected)
+			}
+			required := append([]string(nil), schema.Required...)
+			sort.Strings(required)
+			if !reflect.DeepEqual(required, test.required) {
+				t.Errorf("required of fields.User = %q, expected %q", required, test.required)
+			}
+			nickname, err := json.Marshal(schema.Properties["nickname"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := `{"type":"string","x-nullable":true}`; string(nickname) != expected {
+				t.Errorf("schema of fields.User.nickname = %s, expected %s", nickname, expected)
+			}
+		})
+	}
+}
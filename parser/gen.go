@@ -2,12 +2,14 @@ package parser
 
 import (
 	"fmt"
+	"go/types"
 	"log"
+	"net/http"
+	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/gin-gonic/gin"
 )
 
 var (
@@ -15,14 +17,67 @@ var (
 
 	commentSummaryRegExp     = regexp.MustCompile(`@Summary (.*)`)
 	commentDiscriptionRegExp = regexp.MustCompile(`@Description (.*)`)
-	commentMimeRegExp        = regexp.MustCompile(`@(Accept|Produce) (json-api|json-stream|xml|plain|html|mpfd|x-www-form-urlencoded|json|octet-stream|png|jpeg|gif)`)
+	commentMimeRegExp        = regexp.MustCompile(`@(Accept|Produce) (json-api|json-stream|xml|plain|html|mpfd|x-www-form-urlencoded|json|octet-stream|png|jpeg|gif|[^/\s]+/[^/\s]+)`)
 	commentParamRegExp       = regexp.MustCompile("@Params (.*) (query|path|header|body|formData) (.*) (true|false) (.*)")
 	commentResponseRegExp    = regexp.MustCompile(`@(Success|Failure|Response)`)
 	commentHeaderRegExp      = regexp.MustCompile("@Header")
-	commentRouterRegExp      = regexp.MustCompile(`@Router (.*) (.*) (.*)`)
-	commentPathParamRegExp   = regexp.MustCompile(`((:|\*)(\w*))|(\{(\w*)\})`)
+	commentRouterRegExp      = regexp.MustCompile(`@Router (\S+) (\S+)(.*)`)
+	commentTagsRegExp        = regexp.MustCompile(`@Tags (.*)`)
+	commentSecurityRegExp    = regexp.MustCompile(`@Security (.*)`)
+	commentPathParamRegExp   = regexp.MustCompile(`((:|\*)(\w*))|(\{(\w*)(:[^{}]*|\.\.\.)?\})`)
 )
 
+// ginBinding where a binding of gin reads the params from, and the mime type it accepts.
+type ginBinding struct {
+	in       string
+	mimeType string
+}
+
+// ginBindings the bindings of github.com/gin-gonic/gin/binding keyed by their names.
+var ginBindings = map[string]ginBinding{
+	"JSON":          {in: "body", mimeType: "json"},
+	"XML":           {in: "body", mimeType: "application/xml"},
+	"YAML":          {in: "body", mimeType: "application/x-yaml"},
+	"TOML":          {in: "body", mimeType: "application/toml"},
+	"ProtoBuf":      {in: "body", mimeType: "application/x-protobuf"},
+	"MsgPack":       {in: "body", mimeType: "application/x-msgpack"},
+	"Query":         {in: "query"},
+	"Form":          {in: "formData", mimeType: "x-www-form-urlencoded"},
+	"FormPost":      {in: "formData", mimeType: "x-www-form-urlencoded"},
+	"FormMultipart": {in: "formData", mimeType: "mpfd"},
+	"Uri":           {in: "path"},
+	"Header":        {in: "header"},
+}
+
+// ginBindMethods the bind methods of gin.Context and the bindings they use,
+// empty for the binding chosen by the method and the content type, With for the binding passed.
+var ginBindMethods = map[string]string{
+	"Bind":                   "",
+	"ShouldBind":             "",
+	"BindJSON":               "JSON",
+	"ShouldBindJSON":         "JSON",
+	"ShouldBindBodyWithJSON": "JSON",
+	"BindXML":                "XML",
+	"ShouldBindXML":          "XML",
+	"ShouldBindBodyWithXML":  "XML",
+	"BindYAML":               "YAML",
+	"ShouldBindYAML":         "YAML",
+	"ShouldBindBodyWithYAML": "YAML",
+	"BindTOML":               "TOML",
+	"ShouldBindTOML":         "TOML",
+	"ShouldBindBodyWithTOML": "TOML",
+	"BindQuery":              "Query",
+	"ShouldBindQuery":        "Query",
+	"BindUri":                "Uri",
+	"ShouldBindUri":          "Uri",
+	"BindHeader":             "Header",
+	"ShouldBindHeader":       "Header",
+	"BindWith":               "With",
+	"MustBindWith":           "With",
+	"ShouldBindWith":         "With",
+	"ShouldBindBodyWith":     "With",
+}
+
 type GinSwagger struct {
 	FunctionDesc
 	summaries    []string
@@ -30,55 +85,75 @@ type GinSwagger struct {
 	params       []string
 	failures     []string
 	headers      []string
+	securities   []string
+	tags         string
 	accept       string
 	produce      string
-	success      string
+	successes    []string
 	response     string
-	router       string
+	routers      []string
+	// responseHeaders the headers set by the handler, generated as @Header of the success response
+	responseHeaders []responseHeader
 
 	others []string
 }
 
-func GetGinComments(funcDesc FunctionDesc, routeInfos map[string]gin.RouteInfo) []string {
+// responseHeader a header set in the response, e.g. ctx.Header("X-Request-Id", id).
+type responseHeader struct {
+	name        string
+	description string
+}
 
-	results := []string{}
+func GetGinComments(funcDesc FunctionDesc, routeInfos map[string][]RouteInfo) []string {
+	desc := newGinSwagger(funcDesc)
 
-	desc := &GinSwagger{
+	desc.parseComments()
+	desc.generateComments(routeInfos)
+
+	return desc.comments()
+}
+
+func newGinSwagger(funcDesc FunctionDesc) *GinSwagger {
+	return &GinSwagger{
 		FunctionDesc: funcDesc,
 		summaries:    make([]string, 0),
 		descriptions: make([]string, 0),
 		params:       make([]string, 0),
 		failures:     make([]string, 0),
 		headers:      make([]string, 0),
+		securities:   make([]string, 0),
+		routers:      make([]string, 0),
 		others:       make([]string, 0),
 	}
+}
 
-	desc.parseComments()
-	desc.generateComments(routeInfos)
+func (desc *GinSwagger) comments() []string {
+	results := []string{}
 
 	results = append(results, desc.summaries...)
 	results = append(results, desc.descriptions...)
 	results = append(results, desc.params...)
 	results = append(results, desc.failures...)
-	results = append(results, desc.headers...)
+	results = append(results, desc.securities...)
 	results = append(results, desc.others...)
+	if desc.tags != "" {
+		results = append(results, desc.tags)
+	}
 	if desc.accept != "" {
 		results = append(results, desc.accept)
 	}
 	if desc.produce != "" {
 		results = append(results, desc.produce)
 	}
-	if desc.success != "" {
-		results = append(results, desc.success)
-	}
+	results = append(results, desc.successes...)
 	if desc.response != "" {
 		results = append(results, desc.response)
 	}
-	if desc.router != "" {
-		results = append(results, desc.router)
-	}
+	results = append(results, desc.routers...)
 
 	sort.Strings(results)
+	// the headers are set to the responses declared before them
+	results = append(results, desc.headers...)
 
 	return results
 }
@@ -93,7 +168,7 @@ func (desc *GinSwagger) parseComments() {
 			desc.params = append(desc.params, comment)
 		} else if commentResponseRegExp.MatchString(comment) {
 			if strings.HasPrefix(comment, "// @Success") {
-				desc.success = comment
+				desc.successes = append(desc.successes, comment)
 			} else if strings.HasPrefix(comment, "// @Failure") {
 				desc.failures = append(desc.failures, comment)
 			} else if strings.HasPrefix(comment, "// @Response") {
@@ -110,28 +185,22 @@ func (desc *GinSwagger) parseComments() {
 				desc.produce = comment
 			}
 		} else if commentRouterRegExp.MatchString(comment) {
-			desc.router = comment
+			desc.routers = append(desc.routers, comment)
+		} else if commentTagsRegExp.MatchString(comment) {
+			desc.tags = comment
+		} else if commentSecurityRegExp.MatchString(comment) {
+			desc.securities = append(desc.securities, comment)
 		} else {
 			desc.others = append(desc.others, comment)
 		}
 	}
 }
 
-func (desc *GinSwagger) generateComments(routeInfos map[string]gin.RouteInfo) {
-	// Summery
-	genSummary := fmt.Sprintf("// @Summary %s", desc.Name)
-	isHasSummary := false
-	for _, summary := range desc.summaries {
-		if summary == genSummary {
-			isHasSummary = true
-			break
-		}
-	}
-	if !isHasSummary {
-		desc.summaries = append([]string{genSummary}, desc.summaries...)
-	}
+func (desc *GinSwagger) generateComments(routeInfos map[string][]RouteInfo) {
+	desc.generateSummary()
 
 	pathParams := map[string]string{}
+	routes := handlerRoutes(routeInfos, desc.routeName())
 
 	// Param
 	for _, callExpr := range desc.Exprs {
@@ -139,29 +208,51 @@ func (desc *GinSwagger) generateComments(routeInfos map[string]gin.RouteInfo) {
 
 		// Response
 		switch selector {
-		case "*github.com/gin-gonic/gin.Context.JSON", "*github.com/gin-gonic/gin.Context.JSONP":
-			splitType := strings.Split(callExpr.Args[1].Type, "/")
-			var argType string
-			if len(splitType) == 1 || len(splitType) == 0 {
-				argType = callExpr.Args[1].Type
-			} else {
-				argType = splitType[len(splitType)-1]
-			}
-			if callExpr.Args[0].Name == "http.StatusOK" || callExpr.Args[0].Value == "200" {
-				if len(desc.success) > 0 {
-					continue
-				}
-
-				desc.success = fmt.Sprintf("// @Success %s {object} %s", callExpr.Args[0].Value, argType)
-			} else {
-				desc.failures = append(desc.failures, fmt.Sprintf("// @Failure %s {object} %s", callExpr.Args[0].Value, argType))
-			}
+		case "*github.com/gin-gonic/gin.Context.JSON", "*github.com/gin-gonic/gin.Context.JSONP",
+			"*github.com/gin-gonic/gin.Context.IndentedJSON", "*github.com/gin-gonic/gin.Context.SecureJSON",
+			"*github.com/gin-gonic/gin.Context.PureJSON", "*github.com/gin-gonic/gin.Context.AsciiJSON",
+			"*github.com/gin-gonic/gin.Context.AbortWithStatusJSON":
+			desc.addRenderResponse(callExpr, "json")
 		case "*github.com/gin-gonic/gin.Context.XML":
-			log.Printf("[WARNING] not support %s generate response", selector)
+			desc.addRenderResponse(callExpr, "application/xml")
 		case "*github.com/gin-gonic/gin.Context.YAML":
-			log.Printf("[WARNING] not support %s generate response", selector)
+			desc.addRenderResponse(callExpr, "application/yaml")
+		case "*github.com/gin-gonic/gin.Context.TOML":
+			desc.addRenderResponse(callExpr, "application/toml")
 		case "*github.com/gin-gonic/gin.Context.ProtoBuf":
-			log.Printf("[WARNING] not support %s generate response", selector)
+			desc.addRenderResponse(callExpr, "application/x-protobuf")
+		// ctx.String(http.StatusOK, "hello %s", name)
+		case "*github.com/gin-gonic/gin.Context.String":
+			desc.addStatusResponse(callExpr, "{string} string", "plain")
+		case "*github.com/gin-gonic/gin.Context.HTML":
+			desc.addStatusResponse(callExpr, "{string} string", "html")
+		// ctx.Data(http.StatusOK, "image/png", data)
+		case "*github.com/gin-gonic/gin.Context.Data":
+			mimeType := "octet-stream"
+			if len(callExpr.Args) > 1 {
+				if value, err := strconv.Unquote(callExpr.Args[1].Value); err == nil && mimeTypePattern.MatchString(value) {
+					mimeType = value
+				}
+			}
+			desc.addStatusResponse(callExpr, "{file} file", mimeType)
+		case "*github.com/gin-gonic/gin.Context.File", "*github.com/gin-gonic/gin.Context.FileAttachment",
+			"*github.com/gin-gonic/gin.Context.FileFromFS":
+			desc.addResponse("200", "{file} file")
+			desc.addProduce("octet-stream")
+		// the responses without body, e.g. ctx.Status(http.StatusNoContent) or ctx.Redirect(http.StatusFound, "/login")
+		case "*github.com/gin-gonic/gin.Context.Status", "*github.com/gin-gonic/gin.Context.AbortWithStatus",
+			"*github.com/gin-gonic/gin.Context.Redirect":
+			desc.addStatusResponse(callExpr, "", "")
+		}
+
+		if desc.addHeader(callExpr) {
+			continue
+		}
+
+		// the params bound to a value, e.g. ctx.ShouldBindJSON(&user) or ctx.ShouldBindWith(&form, binding.FormMultipart)
+		if bindingName, exist := ginBindMethods[callExpr.Name]; exist && callExpr.Receiver == "*github.com/gin-gonic/gin.Context" {
+			desc.addBindingParam(callExpr, bindingName, routes, pathParams)
+			continue
 		}
 
 		// Param Query
@@ -216,86 +307,686 @@ func (desc *GinSwagger) generateComments(routeInfos map[string]gin.RouteInfo) {
 				fmt.Sprintf("// @Param %s", argName),
 				fmt.Sprintf("// @Param %s query object false %s", argName, callExpr.Args[0].Value),
 			)
-		case "*github.com/gin-gonic/gin.Context.ShouldBindQuery":
-			splitType := strings.Split(callExpr.Args[0].Type, "/")
-			var argType string
-			if len(splitType) == 1 || len(splitType) == 0 {
-				argType = callExpr.Args[1].Type
-			} else {
-				argType = splitType[len(splitType)-1]
-			}
+		// path param
+		case "*github.com/gin-gonic/gin.Context.Param":
+			argType := desc.typeName(callExpr.Args[0])
 			argName := strings.Trim(callExpr.Args[0].Name, `"`)
+			pathParams[argName] = argType
 			desc.params = appendParam(
 				desc.params,
 				fmt.Sprintf("// @Param %s", argName),
-				fmt.Sprintf(`// @Param %s query %s false "%s"`, argName, argType, argName),
+				fmt.Sprintf(`// @Param %s path %s true "%s"`, argName, argType, argName),
 			)
-		// path param
-		case "*github.com/gin-gonic/gin.Context.Param":
-			splitType := strings.Split(callExpr.Args[0].Type, "/")
-			var argType string
-			if len(splitType) == 1 {
-				argType = callExpr.Args[0].Type
-			} else {
-				argType = splitType[len(splitType)-1]
-			}
+		// form data
+		case "*github.com/gin-gonic/gin.Context.PostForm", "*github.com/gin-gonic/gin.Context.GetPostForm":
 			argName := strings.Trim(callExpr.Args[0].Name, `"`)
 			desc.params = appendParam(
 				desc.params,
 				fmt.Sprintf("// @Param %s", argName),
-				fmt.Sprintf(`// @Param %s path %s true "%s"`, argName, argType, argName),
+				fmt.Sprintf("// @Param %s formData string false %s", argName, callExpr.Args[0].Value),
 			)
-		// post data
-		case "*github.com/gin-gonic/gin.Context.ShouldBindJSON":
-			splitType := strings.Split(callExpr.Args[0].Type, "/")
-			var argType string
-			if len(splitType) == 1 || len(splitType) == 0 {
-				argType = callExpr.Args[1].Type
-			} else {
-				argType = splitType[len(splitType)-1]
-			}
+			desc.addAccept("x-www-form-urlencoded")
+		case "*github.com/gin-gonic/gin.Context.DefaultPostForm":
 			argName := strings.Trim(callExpr.Args[0].Name, `"`)
 			desc.params = appendParam(
 				desc.params,
 				fmt.Sprintf("// @Param %s", argName),
-				fmt.Sprintf(`// @Param %s body %s true "%s"`, argName, argType, argName),
+				fmt.Sprintf("// @Param %s formData string false %s default(%s)", argName, callExpr.Args[0].Value, strings.Trim(callExpr.Args[1].Value, `"`)),
 			)
+			desc.addAccept("x-www-form-urlencoded")
+		case "*github.com/gin-gonic/gin.Context.PostFormArray", "*github.com/gin-gonic/gin.Context.GetPostFormArray":
+			argName := strings.Trim(callExpr.Args[0].Name, `"`)
+			desc.params = appendParam(
+				desc.params,
+				fmt.Sprintf("// @Param %s", argName),
+				fmt.Sprintf("// @Param %s formData []string false %s", argName, callExpr.Args[0].Value),
+			)
+			desc.addAccept("x-www-form-urlencoded")
+		case "*github.com/gin-gonic/gin.Context.FormFile":
+			argName := strings.Trim(callExpr.Args[0].Name, `"`)
+			desc.params = appendParam(
+				desc.params,
+				fmt.Sprintf("// @Param %s", argName),
+				fmt.Sprintf("// @Param %s formData file true %s", argName, callExpr.Args[0].Value),
+			)
+			desc.addAccept("mpfd")
+		case "*github.com/gin-gonic/gin.Context.MultipartForm":
+			desc.addAccept("mpfd")
 		default:
 			// log.Printf("[ERROR] generate swagger Params not support %s method at func %s", selector, desc.fset.Position(desc.source.Pos()))
 		}
 	}
 
-	for key, info := range routeInfos {
-		if strings.HasSuffix(key, desc.PackageName) {
-			if len(desc.router) > 0 {
-				// check swagger Router format
-				swaggerQueryParams := parseQueryPathParams(desc.router)
-				swaggerFormat := commentPathParamRegExp.ReplaceAllString(desc.router, "@@")
-				pathFormat := commentPathParamRegExp.ReplaceAllString(info.Path, "@@")
-				if !strings.ContainsAny(swaggerFormat, pathFormat) {
-					log.Printf("[ERROR] swagger comment %s is not match gin path %s\n", desc.router, info.Path)
-					return
-				}
-				if len(swaggerQueryParams) != len(pathParams) {
-					log.Printf("[ERROR] swagger comment %s's params is not match gin path %s\n", desc.router, info.Path)
-					return
-				}
-				for _, param := range swaggerQueryParams {
-					if _, exist := pathParams[param]; !exist {
-						log.Printf("[ERROR] query path %s, path param %s is not found in func %s %s\n", info.Path, param, desc.Name, desc.fset.Position(desc.source.Pos()))
-						return
-					}
-				}
-			} else {
-				// generate swagger Route comment
-				path := commentPathParamRegExp.ReplaceAllStringFunc(info.Path, func(s string) string {
-					str := strings.TrimPrefix(strings.TrimPrefix(s, ":"), "*")
-					return "{" + str + "}"
-				})
-				desc.router = fmt.Sprintf("// @Router %s [%s] %s", path, info.Method, desc.PackageName)
+	desc.generateHeaders()
+	desc.generateRouter(routeInfos, pathParams)
+}
+
+func (desc *GinSwagger) generateSummary() {
+	// Summery
+	genSummary := fmt.Sprintf("// @Summary %s", desc.Name)
+	isHasSummary := false
+	for _, summary := range desc.summaries {
+		if summary == genSummary {
+			isHasSummary = true
+			break
+		}
+	}
+	if !isHasSummary {
+		desc.summaries = append([]string{genSummary}, desc.summaries...)
+	}
+}
+
+// generateRouter generates a @Router comment for each route of the handler, the @Router comments written already are kept
+// unless none of them matches a route of the handler, then they are replaced.
+// The path params of each route are checked against the params read by the handler.
+func (desc *GinSwagger) generateRouter(routeInfos map[string][]RouteInfo, pathParams map[string]string) {
+	written := desc.routers
+	matched := make(map[string]bool, len(written))
+
+	routes := handlerRoutes(routeInfos, desc.routeName())
+	// the routes of the handler in a stable order
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	desc.generateGroup(routes)
+
+	for _, info := range routes {
+		desc.checkPathParams(info, pathParams)
+
+		path := swaggerPath(info.Path)
+		exist := false
+		for _, router := range written {
+			if routerMatches(router, path, info.Method) {
+				matched[router] = true
+				exist = true
+			}
+		}
+		if exist {
+			continue
+		}
+		desc.routers = append(desc.routers, fmt.Sprintf("// @Router %s [%s] %s", path, info.Method, desc.PackageName))
+	}
+
+	if len(routes) == 0 {
+		return
+	}
+	for _, router := range written {
+		if !matched[router] {
+			log.Printf("[WARNING] swagger comment %s does not match any route of func %s %s\n", router, desc.Name, desc.fset.Position(desc.source.Pos()))
+		}
+	}
+	if len(matched) == 0 {
+		// the @Router comments written are stale, they are replaced by the routes generated
+		desc.routers = desc.routers[len(written):]
+	}
+}
+
+// handlerRoutes returns the routes whose handler is name, e.g. api.Controller.Get for github.com/x/api.(*Controller).Get-fm.
+func handlerRoutes(routeInfos map[string][]RouteInfo, name string) []RouteInfo {
+	routes := make([]RouteInfo, 0)
+	for key, infos := range routeInfos {
+		if matchHandler(normalizeHandler(key), name) {
+			routes = append(routes, infos...)
+		}
+	}
+
+	return routes
+}
+
+// matchHandler reports whether the symbol of a handler names the function, at the boundary of its package path,
+// e.g. github.com/x/api.Create matches api.Create but github.com/x/otherapi.Create and github.com/x/admin.api.Create,
+// the method of type api, do not. The closures named by the compiler match by the function declaring them and
+// their names, e.g. github.com/x/api.List.func1 matches api.List.func1 but not api.List.
+func matchHandler(handler, name string) bool {
+	handlerFunc, handlerClosure := splitClosure(handler)
+	nameFunc, nameClosure := splitClosure(name)
+	if handlerClosure != nameClosure {
+		return false
+	}
+	prefix, found := strings.CutSuffix(handlerFunc, nameFunc)
+
+	return found && (prefix == "" || strings.HasSuffix(prefix, "/"))
+}
+
+// splitClosure splits the name of a closure given by the compiler into the function declaring it and the
+// closure, e.g. github.com/x/api.List and func1.2 of github.com/x/api.List.func1.2, the closure is empty if
+// the name is not of a closure.
+func splitClosure(name string) (string, string) {
+	start := strings.LastIndex(name, "/") + 1
+	parts := strings.Split(name[start:], ".")
+	for index, part := range parts {
+		if index > 0 && isClosureName(part) {
+			return name[:start] + strings.Join(parts[:index], "."), strings.Join(parts[index:], ".")
+		}
+	}
+
+	return name, ""
+}
+
+// isClosureName reports whether the part of a symbol is a closure named by the compiler, e.g. func1.
+func isClosureName(part string) bool {
+	digits, found := strings.CutPrefix(part, "func")
+	if !found || digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeHandler converts the symbol of a handler to the format of FunctionDesc.PackageName,
+// e.g. github.com/x/api.(*Controller).Get-fm to github.com/x/api.Controller.Get.
+func normalizeHandler(handler string) string {
+	handler = strings.TrimSuffix(handler, "-fm")
+	handler = strings.ReplaceAll(handler, "(*", "")
+	return strings.ReplaceAll(handler, ")", "")
+}
+
+// checkPathParams adds the path params of a route which are not read by the handler, and warns about the params
+// read by the handler which are not in the route.
+func (desc *GinSwagger) checkPathParams(info RouteInfo, pathParams map[string]string) {
+	routeParams := parseQueryPathParams(info.Path)
+	for _, param := range routeParams {
+		if _, exist := pathParams[param]; exist {
+			continue
+		}
+		log.Printf("[WARNING] path param %s of route %s %s is not read by func %s %s\n", param, info.Method, info.Path, desc.Name, desc.fset.Position(desc.source.Pos()))
+		desc.params = appendParam(
+			desc.params,
+			fmt.Sprintf("// @Param %s", param),
+			fmt.Sprintf(`// @Param %s path string true "%s"`, param, param),
+		)
+	}
+	for param := range pathParams {
+		if !containsString(routeParams, param) {
+			log.Printf("[WARNING] path param %s read by func %s is not in route %s %s\n", param, desc.Name, info.Method, info.Path)
+		}
+	}
+}
+
+// routerMatches reports whether a @Router comment declares the path and the method.
+func routerMatches(router, path, method string) bool {
+	matches := commentRouterRegExp.FindStringSubmatch(router)
+	if len(matches) < 3 {
+		return false
+	}
+	fields := strings.Fields(matches[1] + " " + matches[2])
+	if len(fields) < 2 {
+		return false
+	}
+
+	return swaggerPath(fields[0]) == path && strings.EqualFold(strings.Trim(fields[1], "[]"), method)
+}
+
+// generateGroup generates the tags and the security of the routes derived from their groups and middlewares,
+// the operation is shared by the routes, so it has the tags of all the routes and the security required by every route.
+func (desc *GinSwagger) generateGroup(infos []RouteInfo) {
+	if len(infos) == 0 {
+		return
+	}
+
+	tags := make([]string, 0)
+	for _, info := range infos {
+		for _, tag := range info.Tags {
+			if !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	if desc.tags == "" && len(tags) > 0 {
+		desc.tags = fmt.Sprintf("// @Tags %s", strings.Join(tags, ","))
+	}
+
+	for _, name := range routeSecurities(infos) {
+		required := true
+		for _, info := range infos {
+			if !containsString(info.Security, name) {
+				required = false
+				break
+			}
+		}
+		if !required {
+			// the security of an operation can not differ between its routes
+			log.Printf("[WARNING] security %s is not required by every route of func %s %s, it is not generated\n", name, desc.Name, desc.fset.Position(desc.source.Pos()))
+			continue
+		}
+		desc.securities = appendParam(
+			desc.securities,
+			fmt.Sprintf("// @Security %s", name),
+			fmt.Sprintf("// @Security %s", name),
+		)
+	}
+}
+
+// routeSecurities returns the names of the securities required by any of the routes.
+func routeSecurities(infos []RouteInfo) []string {
+	names := make([]string, 0)
+	for _, info := range infos {
+		for _, name := range info.Security {
+			if !containsString(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// addHeader adds the header and cookie params read by the handler, and the headers set in the response,
+// it reports whether the call is a header or cookie method.
+func (desc *GinSwagger) addHeader(callExpr ExprItem) bool {
+	selector := fmt.Sprintf("%s.%s", callExpr.Receiver, callExpr.Name)
+
+	switch selector {
+	// ctx.GetHeader("X-Token"), ctx.Request.Header.Get("X-Token") or r.Header.Get("X-Token")
+	case "*github.com/gin-gonic/gin.Context.GetHeader":
+		desc.addNamedParam(callExpr, "header")
+	case "net/http.Header.Get", "net/http.Header.Values":
+		// w.Header().Get reads the response headers
+		if !strings.HasSuffix(callExpr.Target, "Header()") {
+			desc.addNamedParam(callExpr, "header")
+		}
+	// ctx.Cookie("session") or r.Cookie("session")
+	case "*github.com/gin-gonic/gin.Context.Cookie", "*net/http.Request.Cookie":
+		desc.addNamedParam(callExpr, "cookie")
+	// ctx.Header("X-Request-Id", id) or w.Header().Set("X-Request-Id", id)
+	case "*github.com/gin-gonic/gin.Context.Header":
+		desc.addResponseHeader(callExpr, "")
+	case "net/http.Header.Set", "net/http.Header.Add":
+		if strings.HasSuffix(callExpr.Target, "Header()") {
+			desc.addResponseHeader(callExpr, "")
+		}
+	// ctx.SetCookie("session", token, ...) or http.SetCookie(w, cookie)
+	case "*github.com/gin-gonic/gin.Context.SetCookie":
+		desc.addResponseHeader(callExpr, "Set-Cookie")
+	case "net/http.SetCookie":
+		desc.addResponseHeader(ExprItem{}, "Set-Cookie")
+	default:
+		return false
+	}
+
+	return true
+}
+
+// addNamedParam adds a string param of in named by the first argument, which must be a constant.
+func (desc *GinSwagger) addNamedParam(callExpr ExprItem, in string) {
+	if len(callExpr.Args) == 0 {
+		return
+	}
+	name, err := strconv.Unquote(callExpr.Args[0].Value)
+	if err != nil || name == "" {
+		return
+	}
+	desc.params = appendParam(
+		desc.params,
+		fmt.Sprintf("// @Param %s %s ", name, in),
+		fmt.Sprintf(`// @Param %s %s string false "%s"`, name, in, name),
+	)
+}
+
+// addResponseHeader adds a header set in the response, the name is the first argument if header is empty,
+// otherwise the first argument is the description, e.g. the name of the cookie for Set-Cookie.
+func (desc *GinSwagger) addResponseHeader(callExpr ExprItem, header string) {
+	var value string
+	if len(callExpr.Args) > 0 {
+		value, _ = strconv.Unquote(callExpr.Args[0].Value)
+	}
+	name, description := header, value
+	if header == "" {
+		if value == "" {
+			return
+		}
+		name, description = value, value
+	}
+
+	for index, exist := range desc.responseHeaders {
+		if exist.name != name {
+			continue
+		}
+		if description != "" && !containsString(strings.Split(exist.description, ","), description) {
+			desc.responseHeaders[index].description = strings.TrimPrefix(exist.description+","+description, ",")
+		}
+		return
+	}
+	desc.responseHeaders = append(desc.responseHeaders, responseHeader{name: name, description: description})
+}
+
+// generateHeaders generates the @Header comments of the headers set by the handler for the success response,
+// for all the responses if there is no success response.
+func (desc *GinSwagger) generateHeaders() {
+	statuses := make([]string, 0, len(desc.successes))
+	for _, success := range desc.successes {
+		if fields := strings.Fields(success); len(fields) > 2 && !containsString(statuses, fields[2]) {
+			statuses = append(statuses, fields[2])
+		}
+	}
+	status := strings.Join(statuses, ",")
+	if status == "" {
+		status = "all"
+	}
+
+	for _, header := range desc.responseHeaders {
+		description := header.description
+		if description == "" {
+			description = header.name
+		}
+		desc.headers = appendParam(
+			desc.headers,
+			fmt.Sprintf("// @Header %s {string} %s ", status, header.name),
+			fmt.Sprintf(`// @Header %s {string} %s "%s"`, status, header.name, description),
+		)
+	}
+}
+
+// addBindingParam adds the param bound by a bind method, the struct bound from the query, the form, the path or the header
+// is expanded to its fields by the tags form, uri and header when swagger.json is generated.
+func (desc *GinSwagger) addBindingParam(callExpr ExprItem, bindingName string, routes []RouteInfo, pathParams map[string]string) {
+	if len(callExpr.Args) == 0 {
+		return
+	}
+	switch bindingName {
+	case "With":
+		if len(callExpr.Args) < 2 {
+			return
+		}
+		// binding.JSON or a variable of the binding
+		bindingName = callExpr.Args[1].Name[strings.LastIndex(callExpr.Args[1].Name, ".")+1:]
+	case "":
+		// gin binds the form of GET requests, and the body of the others by the content type
+		bindingName = "JSON"
+		if len(routes) > 0 && allMethods(routes, http.MethodGet) {
+			bindingName = "Form"
+		}
+	}
+
+	var binding ginBinding
+	exist := false
+	for name, value := range ginBindings {
+		if strings.EqualFold(name, bindingName) {
+			binding, exist = value, true
+			break
+		}
+	}
+	if !exist {
+		log.Printf("[WARNING] not support binding %s of %s at func %s", bindingName, callExpr.Name, desc.fset.Position(desc.source.Pos()))
+		return
+	}
+	// the form binding reads the query of GET requests
+	if binding.in == "formData" && len(routes) > 0 && allMethods(routes, http.MethodGet) {
+		binding = ginBindings["Query"]
+	}
+
+	arg := callExpr.Args[0]
+	argName := strings.TrimPrefix(arg.Name, "&")
+	required := binding.in == "body" || binding.in == "path"
+	desc.params = appendParam(
+		desc.params,
+		fmt.Sprintf("// @Param %s", argName),
+		fmt.Sprintf(`// @Param %s %s %s %t "%s"`, argName, binding.in, desc.typeName(arg), required, argName),
+	)
+	if binding.mimeType != "" {
+		desc.addAccept(binding.mimeType)
+	}
+	if binding.in == "path" {
+		for _, name := range structTagNames(arg.typ, "uri") {
+			pathParams[name] = "string"
+		}
+	}
+}
+
+// allMethods reports whether all the routes are registered for method.
+func allMethods(routes []RouteInfo, method string) bool {
+	for _, route := range routes {
+		if route.Method != method {
+			return false
+		}
+	}
+
+	return true
+}
+
+// structTagNames returns the names of the fields of a struct by the tag as gin binds them, the field name if no tag,
+// the fields of the embedded structs are included.
+func structTagNames(typ types.Type, tag string) []string {
+	if typ == nil {
+		return nil
+	}
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+	structType, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, structType.NumFields())
+	for index := 0; index < structType.NumFields(); index++ {
+		field := structType.Field(index)
+		name := strings.Split(reflect.StructTag(structType.Tag(index)).Get(tag), ",")[0]
+		if name == "-" || !field.Exported() && !field.Embedded() {
+			continue
+		}
+		if name == "" && field.Embedded() {
+			names = append(names, structTagNames(field.Type(), tag)...)
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// addRenderResponse adds the response of a render method called with the status and the object,
+// e.g. ctx.XML(http.StatusOK, user), mimeType is added to @Produce.
+func (desc *GinSwagger) addRenderResponse(callExpr ExprItem, mimeType string) {
+	if len(callExpr.Args) < 2 {
+		return
+	}
+	schema := ""
+	if callExpr.Args[1].Type != "untyped nil" {
+		schema = desc.responseSchema(callExpr.Args[1])
+	}
+	desc.addStatusResponse(callExpr, schema, mimeType)
+}
+
+// addStatusResponse adds the response of a method called with the status first, e.g. ctx.String(http.StatusOK, "pong"),
+// the response has no body if schema is empty.
+func (desc *GinSwagger) addStatusResponse(callExpr ExprItem, schema, mimeType string) {
+	if len(callExpr.Args) == 0 {
+		return
+	}
+	statuses := statusValues(callExpr.Args[0])
+	if len(statuses) == 0 {
+		log.Printf("[WARNING] the status %s of %s is not a constant at func %s", callExpr.Args[0].Name, callExpr.Name, desc.fset.Position(desc.source.Pos()))
+		return
+	}
+
+	for _, status := range statuses {
+		desc.addResponse(status, schema)
+	}
+	if mimeType != "" {
+		desc.addProduce(mimeType)
+	}
+}
+
+// statusValues returns the possible status codes of an argument, e.g. 200 and 201 for code assigned in branches.
+func statusValues(arg ExprArgItem) []string {
+	statuses := make([]string, 0, len(arg.Values))
+	for _, value := range arg.constantValues() {
+		if _, err := strconv.Atoi(value); err == nil {
+			statuses = append(statuses, value)
+		}
+	}
+
+	return statuses
+}
+
+// addResponse adds a @Success comment for 2xx status, and a @Failure comment for the others, one for each status,
+// schema is the type and the data type of the body, e.g. {object} api.User, the description of the status if empty.
+func (desc *GinSwagger) addResponse(status, schema string) {
+	if schema == "" {
+		code, _ := strconv.Atoi(status)
+		schema = strconv.Quote(http.StatusText(code))
+	}
+
+	if code, err := strconv.Atoi(status); err == nil && code >= 200 && code < 300 {
+		desc.successes = appendParam(
+			desc.successes,
+			fmt.Sprintf("// @Success %s ", status),
+			fmt.Sprintf("// @Success %s %s", status, schema),
+		)
+		return
+	}
+
+	desc.failures = appendParam(
+		desc.failures,
+		fmt.Sprintf("// @Failure %s ", status),
+		fmt.Sprintf("// @Failure %s %s", status, schema),
+	)
+}
+
+// addProduce adds a mime type to @Produce, e.g. // @Produce json,application/xml.
+func (desc *GinSwagger) addProduce(mimeType string) {
+	desc.produce = appendMimeType(desc.produce, "// @Produce", mimeType)
+}
+
+// addAccept adds a mime type to @Accept.
+func (desc *GinSwagger) addAccept(mimeType string) {
+	desc.accept = appendMimeType(desc.accept, "// @Accept", mimeType)
+}
+
+// appendMimeType appends a mime type to the comma separated list of a @Produce or @Accept comment.
+func appendMimeType(comment, prefix, mimeType string) string {
+	if comment == "" {
+		return prefix + " " + mimeType
+	}
+
+	list := strings.TrimSpace(strings.TrimPrefix(comment, prefix))
+	for _, item := range strings.Split(list, ",") {
+		if item == mimeType || mimeTypeAliases[item] == mimeType || item == mimeTypeAliases[mimeType] {
+			return comment
+		}
+	}
+
+	return comment + "," + mimeType
+}
+
+// genericObjects the types of the response bodies documented as any object.
+var genericObjects = map[string]bool{
+	"interface{}":                true,
+	"any":                        true,
+	"map[string]interface{}":     true,
+	"map[string]any":             true,
+	"github.com/gin-gonic/gin.H": true,
+}
+
+// responseSchema returns the schema of a response body, e.g. {array} api.User for []*api.User,
+// {object} object{id=integer} for gin.H{"id": 1} and {object} object for the other interface{} and gin.H.
+func (desc *FunctionDesc) responseSchema(arg ExprArgItem) string {
+	if arg.Schema != "" {
+		return "{object} " + arg.Schema
+	}
+	typeName := desc.typeName(arg)
+	if strings.HasPrefix(typeName, "[]") {
+		return "{array} " + strings.TrimPrefix(typeName, "[]")
+	}
+
+	return "{object} " + typeName
+}
+
+// typeName returns the name of the type of an argument in the annotations of the function.
+func (desc *FunctionDesc) typeName(arg ExprArgItem) string {
+	if arg.typ == nil {
+		return OBJECT
+	}
+
+	return schemaTypeName(arg.typ, desc.qualifier)
+}
+
+// schemaTypeName returns the name of a type in the annotations, the pointers are dereferenced and the packages are
+// named by qualifier, e.g. integer for int64, []models.User for []*models.User, and the import path of a package
+// not imported by the file, e.g. github.com/x/models.User, which keeps the packages of the same name apart.
+func schemaTypeName(typ types.Type, qualifier types.Qualifier) string {
+	switch t := typ.(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			return BOOLEAN
+		case t.Info()&types.IsInteger != 0:
+			return INTEGER
+		case t.Info()&types.IsFloat != 0:
+			return NUMBER
+		case t.Info()&types.IsString != 0:
+			return STRING
+		}
+		return OBJECT
+	case *types.Pointer:
+		return schemaTypeName(t.Elem(), qualifier)
+	case *types.Slice:
+		// encoding/json encodes []byte as a base64 string
+		if basic, ok := t.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return STRING
+		}
+		return "[]" + schemaTypeName(t.Elem(), qualifier)
+	case *types.Array:
+		return "[]" + schemaTypeName(t.Elem(), qualifier)
+	case *types.Map:
+		if key, ok := t.Key().Underlying().(*types.Basic); ok && key.Info()&types.IsString != 0 {
+			if elem := schemaTypeName(t.Elem(), qualifier); elem != OBJECT {
+				return "map[string]" + elem
 			}
 		}
+		return OBJECT
+	case *types.Alias:
+		// the well-known aliases, e.g. json.RawMessage
+		if obj := t.Obj(); obj.Pkg() != nil {
+			if _, known := WellKnownTypes[obj.Pkg().Path()+"."+obj.Name()]; known {
+				return qualifier(obj.Pkg()) + "." + obj.Name()
+			}
+		}
+		return schemaTypeName(types.Unalias(t), qualifier)
+	case *types.Named:
+		if genericObjects[t.String()] {
+			return OBJECT
+		}
+		obj := t.Obj()
+		if obj.Pkg() == nil {
+			// error
+			return schemaTypeName(t.Underlying(), qualifier)
+		}
+		// the named basic types of the standard library which are not well-known, e.g. http.Header
+		_, known := WellKnownTypes[obj.Pkg().Path()+"."+obj.Name()]
+		if _, ok := t.Underlying().(*types.Struct); !ok && !known && isStandardPackage(obj.Pkg().Path()) {
+			return schemaTypeName(t.Underlying(), qualifier)
+		}
+		// the instances of the generic types, e.g. api.Response[models.User] for api.Response[*models.User],
+		// whose arguments are named as in Go
+		if t.TypeArgs().Len() > 0 {
+			args := make([]string, t.TypeArgs().Len())
+			for index := range args {
+				args[index] = strings.NewReplacer(" ", "", "*", "").Replace(types.TypeString(t.TypeArgs().At(index), qualifier))
+			}
+			return qualifier(obj.Pkg()) + "." + obj.Name() + "[" + strings.Join(args, ",") + "]"
+		}
+		return types.TypeString(t, qualifier)
 	}
+
+	return OBJECT
+}
+
+// isStandardPackage reports whether the package is in the standard library, whose first path element has no dot.
+func isStandardPackage(pkgPath string) bool {
+	return !strings.Contains(strings.Split(pkgPath, "/")[0], ".")
 }
 
 func appendParam(params []string, p, source string) []string {
@@ -317,10 +1008,30 @@ func parseQueryPathParams(path string) []string {
 	results := []string{}
 	paramStrs := commentPathParamRegExp.FindAllString(path, -1)
 	for _, param := range paramStrs {
-		str := strings.TrimPrefix(strings.TrimPrefix(param, ":"), "*")
-		str = strings.TrimSuffix(strings.TrimPrefix(str, "{"), "}")
-		results = append(results, str)
-
+		if str := pathParamName(param); str != "" {
+			results = append(results, str)
+		}
 	}
 	return results
 }
+
+// swaggerPath converts the path params of a route, e.g. :id, *path, {id:[0-9]+} or {path...}, to {id}.
+func swaggerPath(path string) string {
+	return commentPathParamRegExp.ReplaceAllStringFunc(path, func(s string) string {
+		name := pathParamName(s)
+		if name == "" {
+			return s
+		}
+		return "{" + name + "}"
+	})
+}
+
+func pathParamName(param string) string {
+	str := strings.TrimPrefix(strings.TrimPrefix(param, ":"), "*")
+	str = strings.TrimSuffix(strings.TrimPrefix(str, "{"), "}")
+	str = strings.TrimSuffix(str, "...")
+	if index := strings.Index(str, ":"); index >= 0 {
+		str = str[:index]
+	}
+	return str
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"strconv"
+	"strings"
+)
+
+// GetHTTPComments generates the swagger comments of a net/http handler, func(http.ResponseWriter, *http.Request).
+func GetHTTPComments(funcDesc FunctionDesc, routeInfos map[string][]RouteInfo) []string {
+	desc := newGinSwagger(funcDesc)
+
+	desc.parseComments()
+	desc.generateHTTPComments(routeInfos)
+
+	return desc.comments()
+}
+
+func (desc *GinSwagger) generateHTTPComments(routeInfos map[string][]RouteInfo) {
+	desc.generateSummary()
+
+	pathParams := map[string]string{}
+	// the statuses written by w.WriteHeader, net/http responds 200 by default, the statuses written in a branch
+	// returning at its end are of the responses of the branch, e.g. if err != nil { w.WriteHeader(400); ...; return }
+	statuses := []string{"200"}
+	var branch ast.Node
+	// written reports whether the statuses are written by w.WriteHeader without a body yet, they are responded
+	// without a body unless a body is written before the branch returns, e.g. w.WriteHeader(404); return
+	written := false
+	respond := func() {
+		if written {
+			for _, status := range statuses {
+				desc.addResponse(status, "")
+			}
+		}
+		statuses, branch, written = []string{"200"}, nil, false
+	}
+
+	for _, callExpr := range desc.Exprs {
+		if branch != nil && !containsNode(branch, callExpr.branch) {
+			respond()
+		}
+		selector := fmt.Sprintf("%s.%s", callExpr.Receiver, callExpr.Name)
+		if desc.addHeader(callExpr) {
+			continue
+		}
+
+		switch selector {
+		// query, r.URL.Query().Get("name") or r.Form.Get("name"), form, r.PostForm.Get("name")
+		case "net/url.Values.Get", "net/url.Values.Has":
+			desc.addNamedParam(callExpr, valuesLocation(callExpr.Target))
+		case "*net/http.Request.FormValue", "*net/http.Request.PostFormValue":
+			in := "formData"
+			if callExpr.Name == "FormValue" {
+				in = "query"
+			}
+			desc.addNamedParam(callExpr, in)
+		// path param, r.PathValue("id"), the name is a constant
+		case "*net/http.Request.PathValue":
+			if len(callExpr.Args) == 0 {
+				continue
+			}
+			name, err := strconv.Unquote(callExpr.Args[0].Value)
+			if err != nil || name == "" {
+				continue
+			}
+			pathParams[name] = "string"
+			desc.params = appendParam(
+				desc.params,
+				fmt.Sprintf("// @Param %s path ", name),
+				fmt.Sprintf(`// @Param %s path string true "%s"`, name, name),
+			)
+		// post data, json.NewDecoder(r.Body).Decode(&req)
+		case "*encoding/json.Decoder.Decode":
+			if len(callExpr.Args) == 0 {
+				continue
+			}
+			argType := desc.typeName(callExpr.Args[0])
+			argName := strings.TrimPrefix(callExpr.Args[0].Name, "&")
+			desc.params = appendParam(
+				desc.params,
+				fmt.Sprintf("// @Param %s", argName),
+				fmt.Sprintf(`// @Param %s body %s true "%s"`, argName, argType, argName),
+			)
+			desc.addAccept("json")
+		// response status, w.WriteHeader(http.StatusCreated)
+		case "net/http.ResponseWriter.WriteHeader":
+			if len(callExpr.Args) == 0 || len(statusValues(callExpr.Args[0])) == 0 {
+				continue
+			}
+			respond()
+			statuses, branch, written = statusValues(callExpr.Args[0]), callExpr.branch, true
+		// response, json.NewEncoder(w).Encode(resp)
+		case "*encoding/json.Encoder.Encode":
+			if len(callExpr.Args) == 0 {
+				continue
+			}
+			for _, status := range statuses {
+				desc.addResponse(status, desc.responseSchema(callExpr.Args[0]))
+			}
+			desc.addProduce("json")
+			// the status is written with the body, the responses of the other paths are 200 unless they write theirs
+			statuses, written = []string{"200"}, false
+		case "net/http.ResponseWriter.Write":
+			for _, status := range statuses {
+				desc.addResponse(status, "{string} string")
+			}
+			statuses, written = []string{"200"}, false
+		// http.Error(w, "message", http.StatusBadRequest)
+		case "net/http.Error":
+			if len(callExpr.Args) < 3 {
+				continue
+			}
+			for _, status := range statusValues(callExpr.Args[2]) {
+				desc.addResponse(status, "{string} string")
+			}
+		}
+	}
+
+	respond()
+
+	desc.generateHeaders()
+	desc.generateRouter(routeInfos, pathParams)
+}
+
+// valuesLocation returns where the url.Values a parameter is read from are sent, by the selector chain of the
+// values: formData for r.PostForm, query for r.URL.Query() and r.Form, which includes the query.
+func valuesLocation(target string) string {
+	expr, err := goparser.ParseExpr(target)
+	if err != nil {
+		return "query"
+	}
+	if selector, ok := expr.(*ast.SelectorExpr); ok && selector.Sel.Name == "PostForm" {
+		return "formData"
+	}
+
+	return "query"
+}
+
+// containsNode reports whether the node is inside the parent.
+func containsNode(parent, node ast.Node) bool {
+	return node != nil && parent.Pos() <= node.Pos() && node.End() <= parent.End()
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetHTTPComments(t *testing.T) {
+	comments := generateFileComments(t, "testdata/nethttp", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:    "status written in the error branch",
+			handler: "nethttp.CreateUser",
+			expected: []string{
+				"// @Accept json",
+				"// @Failure 400 {object} nethttp.Error",
+				`// @Param user body nethttp.User true "user"`,
+				"// @Produce json",
+				"// @Success 200 {object} nethttp.User",
+				"// @Summary CreateUser",
+			},
+		},
+		{
+			name:    "status written without a body",
+			handler: "nethttp.GetUser",
+			expected: []string{
+				`// @Failure 404 "Not Found"`,
+				`// @Param id path string true "id"`,
+				"// @Produce json",
+				"// @Success 200 {object} nethttp.User",
+				"// @Summary GetUser",
+			},
+		},
+		{
+			name:    "params named by constants",
+			handler: "nethttp.SearchUsers",
+			expected: []string{
+				`// @Param name query string false "name"`,
+				`// @Param page formData string false "page"`,
+				`// @Param size query string false "size"`,
+				"// @Success 200 {string} string",
+				"// @Summary SearchUsers",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
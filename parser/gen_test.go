@@ -0,0 +1,546 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchHandler(t *testing.T) {
+	tests := []struct {
+		handler  string
+		name     string
+		expected bool
+	}{
+		{handler: "github.com/x/api.Create", name: "api.Create", expected: true},
+		{handler: "github.com/x/otherapi.Create", name: "api.Create", expected: false},
+		{handler: "github.com/x/admin.user.List", name: "user.List", expected: false},
+		{handler: "github.com/x/user.List", name: "user.List", expected: true},
+		{handler: "github.com/x/api.Controller.Get", name: "api.Controller.Get", expected: true},
+		{handler: "main.main.func1", name: "main.main.func1", expected: true},
+		{handler: "github.com/x/api.NewHandler.func1", name: "api.NewHandler.func1", expected: true},
+		{handler: "github.com/x/api.NewHandler.func1", name: "api.NewHandler", expected: false},
+		{handler: "github.com/x/api.NewHandler.func1.2", name: "api.NewHandler.func1", expected: false},
+		{handler: "github.com/x/admin.api.NewHandler.func1", name: "api.NewHandler.func1", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.handler+"/"+test.name, func(t *testing.T) {
+			if got := matchHandler(test.handler, test.name); got != test.expected {
+				t.Errorf("matchHandler(%q, %q) = %t, expected %t", test.handler, test.name, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestHandlerRoutes(t *testing.T) {
+	routeInfos := map[string][]RouteInfo{
+		"github.com/x/api.(*Controller).Get-fm": {{Method: "GET", Path: "/users/:id"}},
+		"github.com/x/admin.Controller.Get":     {{Method: "GET", Path: "/admin/users/:id"}},
+	}
+
+	routes := handlerRoutes(routeInfos, "api.Controller.Get")
+	if len(routes) != 1 || routes[0].Path != "/users/:id" {
+		t.Errorf("handlerRoutes(api.Controller.Get) = %+v, expected the route /users/:id", routes)
+	}
+}
+
+func TestGenerateRouter(t *testing.T) {
+	const pkg = "github.com/Scterl/go-swagger/parser/testdata/routers."
+	comments := generateFileComments(t, "testdata/routers", []RouteInfo{
+		{Method: "GET", Path: "/users", Handler: pkg + "Stale"},
+		{Method: "GET", Path: "/users/:id", Handler: pkg + "Kept"},
+		{Method: "GET", Path: "/items", Handler: pkg + "Partial"},
+		{Method: "POST", Path: "/items", Handler: pkg + "Partial"},
+	})
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:     "stale routers replaced",
+			handler:  "routers.Stale",
+			expected: []string{"// @Router /users [GET] routers.Stale"},
+		},
+		{
+			name:     "written router kept",
+			handler:  "routers.Kept",
+			expected: []string{"// @Router /users/{id} [get]"},
+		},
+		{
+			name:    "written routers kept with the routes missing",
+			handler: "routers.Partial",
+			expected: []string{
+				"// @Router /items [POST] routers.Partial",
+				"// @Router /items [get]",
+				"// @Router /legacy/items [get]",
+			},
+		},
+		{
+			name:     "written router of no route kept",
+			handler:  "routers.Unrouted",
+			expected: []string{"// @Router /unrouted [get]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			routers := make([]string, 0)
+			for _, comment := range comments[test.handler] {
+				if strings.HasPrefix(comment, "// @Router") {
+					routers = append(routers, comment)
+				}
+			}
+			if !reflect.DeepEqual(routers, test.expected) {
+				t.Errorf("routers of %s = %q, expected %q", test.handler, routers, test.expected)
+			}
+		})
+	}
+}
+
+func TestFollowCalls(t *testing.T) {
+	comments := generateFileComments(t, "testdata/calls", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:    "binding and responses in the helpers",
+			handler: "calls.GetUser",
+			expected: []string{
+				"// @Accept json",
+				"// @Failure 400 {object} calls.Error",
+				`// @Param user body calls.User true "user"`,
+				"// @Produce json",
+				"// @Success 200 {object} calls.User",
+				"// @Summary GetUser",
+			},
+		},
+		{
+			name:    "recursive calls",
+			handler: "calls.Retry",
+			expected: []string{
+				"// @Produce json",
+				"// @Success 200 {object} calls.User",
+				"// @Summary Retry",
+				"// Retry calls itself, the recursive calls are followed once.",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateGroup(t *testing.T) {
+	const pkg = "github.com/Scterl/go-swagger/parser/testdata/routers."
+	comments := generateFileComments(t, "testdata/routers", []RouteInfo{
+		{Method: "GET", Path: "/users/:id", Handler: pkg + "Kept", Tags: []string{"users"}, Security: []string{"JWT", "BasicAuth"}},
+		{Method: "GET", Path: "/items", Handler: pkg + "Partial", Tags: []string{"items"}, Security: []string{"JWT"}},
+		{Method: "POST", Path: "/items", Handler: pkg + "Partial", Tags: []string{"admin"}, Security: []string{"JWT", "BasicAuth"}},
+	})
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:     "tags and security of the route",
+			handler:  "routers.Kept",
+			expected: []string{"// @Security BasicAuth", "// @Security JWT", "// @Tags users"},
+		},
+		{
+			name:     "security required by every route",
+			handler:  "routers.Partial",
+			expected: []string{"// @Security JWT", "// @Tags items,admin"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			groups := make([]string, 0)
+			for _, comment := range comments[test.handler] {
+				if strings.HasPrefix(comment, "// @Tags") || strings.HasPrefix(comment, "// @Security") {
+					groups = append(groups, comment)
+				}
+			}
+			if !reflect.DeepEqual(groups, test.expected) {
+				t.Errorf("tags and security of %s = %q, expected %q", test.handler, groups, test.expected)
+			}
+		})
+	}
+}
+
+func TestClosureHandlers(t *testing.T) {
+	const pkg = "github.com/Scterl/go-swagger/parser/testdata/closures."
+	comments := generateFileComments(t, "testdata/closures", []RouteInfo{
+		{Method: "GET", Path: "/users/:id", Handler: pkg + "(*Controller).Get-fm"},
+		{Method: "GET", Path: "/users", Handler: pkg + "ListUsers.func1"},
+		{Method: "DELETE", Path: "/users/:id", Handler: pkg + "Register.func1"},
+	})
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:    "method value",
+			handler: "closures.Controller.Get",
+			expected: []string{
+				`// @Param id path string true "id"`,
+				"// @Produce json",
+				"// @Router /users/{id} [GET] closures.Controller.Get",
+				"// @Success 200 {object} closures.User",
+				"// @Summary Get",
+			},
+		},
+		{
+			name:    "closure returned by a handler factory",
+			handler: "closures.ListUsers",
+			expected: []string{
+				"// @Produce json",
+				"// @Router /users [GET] closures.ListUsers",
+				"// @Success 200 {array} closures.User",
+				"// @Summary ListUsers",
+			},
+		},
+		{
+			name:    "inline closure",
+			handler: "closures.Register.func1",
+			expected: []string{
+				`// @Param id path string true "id"`,
+				"// @Router /users/{id} [DELETE] closures.Register.func1",
+				`// @Success 204 "No Content"`,
+				"// @Summary Register.func1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenderResponses(t *testing.T) {
+	comments := generateFileComments(t, "testdata/render", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:     "xml",
+			handler:  "render.GetXML",
+			expected: []string{"// @Produce application/xml", "// @Success 200 {object} render.User", "// @Summary GetXML"},
+		},
+		{
+			name:     "yaml",
+			handler:  "render.GetYAML",
+			expected: []string{"// @Produce application/yaml", "// @Success 200 {object} render.User", "// @Summary GetYAML"},
+		},
+		{
+			name:     "indented json",
+			handler:  "render.GetIndented",
+			expected: []string{"// @Produce json", "// @Success 201 {array} render.User", "// @Summary GetIndented"},
+		},
+		{
+			name:    "string",
+			handler: "render.GetString",
+			expected: []string{
+				`// @Param name query string false "name"`,
+				"// @Produce plain",
+				"// @Success 200 {string} string",
+				"// @Summary GetString",
+			},
+		},
+		{
+			name:     "data of a mime type",
+			handler:  "render.GetImage",
+			expected: []string{"// @Produce image/png", "// @Success 200 {file} file", "// @Summary GetImage"},
+		},
+		{
+			name:     "file",
+			handler:  "render.Download",
+			expected: []string{"// @Produce octet-stream", "// @Success 200 {file} file", "// @Summary Download"},
+		},
+		{
+			name:     "redirect",
+			handler:  "render.Redirect",
+			expected: []string{`// @Failure 302 "Found"`, "// @Summary Redirect"},
+		},
+		{
+			name:     "status without a body",
+			handler:  "render.Delete",
+			expected: []string{`// @Success 204 "No Content"`, "// @Summary Delete"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestBindingParams(t *testing.T) {
+	const pkg = "github.com/Scterl/go-swagger/parser/testdata/binding."
+	comments := generateFileComments(t, "testdata/binding", []RouteInfo{
+		{Method: "GET", Path: "/search", Handler: pkg + "Search"},
+		{Method: "POST", Path: "/users", Handler: pkg + "Save"},
+		{Method: "GET", Path: "/users/:id", Handler: pkg + "GetUser"},
+	})
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:     "query",
+			handler:  "binding.ListUsers",
+			expected: []string{`// @Param query query binding.Query false "query"`},
+		},
+		{
+			name:    "uri and header",
+			handler: "binding.GetUser",
+			expected: []string{
+				`// @Param header header binding.Header false "header"`,
+				`// @Param uri path binding.URI true "uri"`,
+			},
+		},
+		{
+			name:     "xml body",
+			handler:  "binding.CreateUser",
+			expected: []string{"// @Accept application/xml", `// @Param user body binding.User true "user"`},
+		},
+		{
+			name:    "form and file",
+			handler: "binding.Upload",
+			expected: []string{
+				"// @Accept mpfd,x-www-form-urlencoded",
+				`// @Param avatar formData file true "avatar"`,
+				`// @Param nickname formData string false "nickname"`,
+			},
+		},
+		{
+			name:     "bind of a GET route",
+			handler:  "binding.Search",
+			expected: []string{`// @Param form query binding.Form false "form"`},
+		},
+		{
+			name:     "bind of a POST route",
+			handler:  "binding.Save",
+			expected: []string{"// @Accept json", `// @Param user body binding.User true "user"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := make([]string, 0)
+			for _, comment := range comments[test.handler] {
+				if strings.HasPrefix(comment, "// @Param") || strings.HasPrefix(comment, "// @Accept") {
+					params = append(params, comment)
+				}
+			}
+			if !reflect.DeepEqual(params, test.expected) {
+				t.Errorf("params of %s = %q, expected %q", test.handler, params, test.expected)
+			}
+		})
+	}
+}
+
+func TestHeaderParams(t *testing.T) {
+	comments := generateFileComments(t, "testdata/headers", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:    "gin",
+			handler: "headers.GinHeaders",
+			expected: []string{
+				`// @Param X-Token header string false "X-Token"`,
+				`// @Param session cookie string false "session"`,
+				`// @Success 200 "OK"`,
+				"// @Summary GinHeaders",
+				`// @Header 200 {string} X-Request-Id "X-Request-Id"`,
+			},
+		},
+		{
+			name:    "net/http",
+			handler: "headers.HTTPHeaders",
+			expected: []string{
+				`// @Param Authorization header string false "Authorization"`,
+				`// @Param session cookie string false "session"`,
+				`// @Success 204 "No Content"`,
+				"// @Summary HTTPHeaders",
+				`// @Header 204 {string} X-Rate-Limit "X-Rate-Limit"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestStatusValues(t *testing.T) {
+	comments := generateFileComments(t, "testdata/status", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:    "constant and variable statuses",
+			handler: "status.CreateUser",
+			expected: []string{
+				"// @Failure 409 {object} status.Error",
+				`// @Param async query string false "async"`,
+				"// @Produce json",
+				"// @Success 202 {object} status.User",
+				"// @Summary CreateUser",
+			},
+		},
+		{
+			name:    "status assigned in branches and an interface payload",
+			handler: "status.GetUser",
+			expected: []string{
+				"// @Failure 404 {object} object",
+				`// @Param missing query string false "missing"`,
+				"// @Produce json",
+				"// @Success 200 {object} object",
+				"// @Summary GetUser",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestLiteralResponses(t *testing.T) {
+	comments := generateFileComments(t, "testdata/literals", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:    "gin.H",
+			handler: "literals.GetUser",
+			expected: []string{
+				"// @Produce json",
+				"// @Success 200 {object} object{ok=boolean,total=integer,user=literals.User}",
+				"// @Summary GetUser",
+			},
+		},
+		{
+			name:    "map literal",
+			handler: "literals.ListTags",
+			expected: []string{
+				"// @Produce json",
+				"// @Success 200 {object} object{tags=[]string}",
+				"// @Summary ListTags",
+			},
+		},
+		{
+			name:    "failure",
+			handler: "literals.GetError",
+			expected: []string{
+				"// @Failure 400 {object} object{error=string}",
+				`// @Param reason query string false "reason"`,
+				"// @Produce json",
+				"// @Summary GetError",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestQualifiedTypeNames(t *testing.T) {
+	comments := generateFileComments(t, "testdata/qualified/api", nil)
+
+	tests := []struct {
+		name     string
+		handler  string
+		expected []string
+	}{
+		{
+			name:     "imported package",
+			handler:  "api.GetUser",
+			expected: []string{"// @Produce json", "// @Success 200 {object} models.User", "// @Summary GetUser"},
+		},
+		{
+			name:    "package imported with an alias",
+			handler: "api.GetUserV2",
+			expected: []string{
+				"// @Accept json",
+				`// @Param user body v2.User true "user"`,
+				"// @Produce json",
+				"// @Success 200 {array} v2.User",
+				"// @Summary GetUserV2",
+			},
+		},
+		{
+			name:    "package not imported by the file",
+			handler: "api.GetOrder",
+			expected: []string{
+				"// @Produce json",
+				"// @Success 200 {object} github.com/Scterl/go-swagger/parser/testdata/qualified/orders.Order",
+				"// @Summary GetOrder",
+			},
+		},
+		{
+			name:     "own package",
+			handler:  "api.ListUsers",
+			expected: []string{"// @Produce json", "// @Success 200 {object} map[string]api.Page", "// @Summary ListUsers"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := comments[test.handler]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("comments of %s = %q, expected %q", test.handler, got, test.expected)
+			}
+		})
+	}
+}
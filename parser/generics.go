@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// splitGenericTypeName splits an instantiated generic type into the generic type and its type arguments,
+// e.g. Response and [models.User] for Response[models.User], ok is false if the type is not generic.
+func splitGenericTypeName(typeName string) (name string, args []string, ok bool) {
+	start := strings.Index(typeName, "[")
+	if start <= 0 || !strings.HasSuffix(typeName, "]") || strings.HasPrefix(typeName, "map[") {
+		return typeName, nil, false
+	}
+
+	depth, last := 0, start+1
+	for index := start + 1; index < len(typeName)-1; index++ {
+		switch typeName[index] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(typeName[last:index]))
+				last = index + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(typeName[last:len(typeName)-1]))
+
+	return typeName[:start], args, true
+}
+
+// instantiate finds out the instance of the generic type name with the type arguments args used in file, e.g.
+// Response[models.User]. The instance is a type spec named by the generic type and the arguments, e.g.
+// Response-models_User, in the package of the generic type, whose type params are replaced by the arguments.
+func (pkgs *PackagesDefinitions) instantiate(name string, args []string, file *ast.File, parseDependency bool) *TypeSpecDef {
+	generic := pkgs.FindTypeSpec(name, file, parseDependency)
+	if generic == nil || generic.TypeSpec.TypeParams == nil {
+		return nil
+	}
+	params := make([]string, 0, len(args))
+	for _, field := range generic.TypeSpec.TypeParams.List {
+		for _, param := range field.Names {
+			params = append(params, param.Name)
+		}
+	}
+	if len(params) != len(args) {
+		return nil
+	}
+
+	// the arguments are qualified by the import paths to be resolved in the file of the generic type
+	qualified := make([]string, len(args))
+	names := make([]string, len(args))
+	for index, arg := range args {
+		qualified[index], names[index] = pkgs.qualifyTypeArg(arg, file, parseDependency)
+	}
+	key := generic.PkgPath + "." + generic.Name() + "[" + strings.Join(qualified, ",") + "]"
+	if instance, exist := pkgs.instances[key]; exist {
+		return instance
+	}
+
+	definitions := pkgs.packages[generic.PkgPath]
+	if definitions == nil {
+		return nil
+	}
+	instanceName := generic.Name() + "-" + strings.Join(names, "-")
+	if existing, exist := definitions.TypeDefinitions[instanceName]; exist {
+		// the arguments of the same name from different packages, e.g. Response[user/models.User] and Response[order/models.User],
+		// all the instances of the name are qualified, the one found before is renamed, the name is kept to find it
+		if existing.TypeSpec.Name.Name == instanceName {
+			pkgs.renameInstance(existing, generic.Name())
+		}
+		instanceName = qualifiedInstanceName(generic.Name(), qualified)
+	}
+
+	bindings := make(map[string]ast.Expr, len(params))
+	for index, param := range params {
+		bindings[param] = typeArgExpr(qualified[index])
+	}
+	instance := &TypeSpecDef{
+		PkgPath: generic.PkgPath,
+		File:    generic.File,
+		TypeSpec: &ast.TypeSpec{
+			Name: &ast.Ident{Name: instanceName, NamePos: generic.TypeSpec.Name.NamePos},
+			Type: substituteTypeParams(generic.TypeSpec.Type, bindings),
+		},
+	}
+	pkgs.instances[key] = instance
+	definitions.TypeDefinitions[instanceName] = instance
+
+	return instance
+}
+
+// renameInstance renames an instance by its qualified type arguments, e.g. Response-github_com_x_models_User
+// for Response-models_User, its former name is kept to rename the references to it.
+func (pkgs *PackagesDefinitions) renameInstance(instance *TypeSpecDef, genericName string) {
+	for key, found := range pkgs.instances {
+		if found != instance {
+			continue
+		}
+		_, qualified, _ := splitGenericTypeName(key)
+		if pkgs.renamedInstances == nil {
+			pkgs.renamedInstances = make(map[*TypeSpecDef]string)
+		}
+		pkgs.renamedInstances[instance] = instance.Name()
+		instance.TypeSpec.Name.Name = qualifiedInstanceName(genericName, qualified)
+		pkgs.packages[instance.PkgPath].TypeDefinitions[instance.Name()] = instance
+
+		return
+	}
+}
+
+func qualifiedInstanceName(genericName string, qualified []string) string {
+	return genericName + "-" + strings.NewReplacer("/", "_", ".", "_").Replace(strings.Join(qualified, "-"))
+}
+
+// qualifyTypeArg returns the type argument arg used in file qualified by the import paths, e.g.
+// []github.com/x/models.User for []*models.User, and its name in the name of the instance, e.g. array_models_User.
+func (pkgs *PackagesDefinitions) qualifyTypeArg(arg string, file *ast.File, parseDependency bool) (string, string) {
+	arg = strings.TrimLeft(arg, "*")
+
+	switch {
+	case strings.HasPrefix(arg, "[]"):
+		qualified, name := pkgs.qualifyTypeArg(arg[2:], file, parseDependency)
+		return "[]" + qualified, "array_" + name
+	case strings.HasPrefix(arg, "map["):
+		end := strings.Index(arg, "]")
+		if end < 0 {
+			return arg, arg
+		}
+		qualified, name := pkgs.qualifyTypeArg(arg[end+1:], file, parseDependency)
+		return arg[:end+1] + qualified, "map_" + arg[4:end] + "_" + name
+	case arg == "interface{}" || arg == "any":
+		return "interface{}", "any"
+	case IsGolangPrimitiveType(arg):
+		return arg, arg
+	}
+
+	typeSpecDef := pkgs.FindTypeSpec(arg, file, parseDependency)
+	if typeSpecDef == nil {
+		// left to the parser, e.g. time.Time
+		return pkgs.qualifiedTypeName(arg, file), strings.ReplaceAll(arg, ".", "_")
+	}
+
+	return typeSpecDef.PkgPath + "." + typeSpecDef.Name(), strings.ReplaceAll(typeSpecDef.FullName(), ".", "_")
+}
+
+// typeArgExpr returns the type expression of a qualified type argument, the named types are identifiers
+// of the qualified names, which are resolved by PackagesDefinitions.FindTypeSpec.
+func typeArgExpr(arg string) ast.Expr {
+	switch {
+	case strings.HasPrefix(arg, "[]"):
+		return &ast.ArrayType{Elt: typeArgExpr(arg[2:])}
+	case strings.HasPrefix(arg, "map["):
+		end := strings.Index(arg, "]")
+		return &ast.MapType{Key: ast.NewIdent(arg[4:end]), Value: typeArgExpr(arg[end+1:])}
+	case arg == "interface{}":
+		return &ast.InterfaceType{Methods: &ast.FieldList{}}
+	}
+
+	return ast.NewIdent(arg)
+}
+
+// substituteTypeParams returns a copy of the type expression whose type params are replaced by bindings.
+func substituteTypeParams(expr ast.Expr, bindings map[string]ast.Expr) ast.Expr {
+	switch typeExpr := expr.(type) {
+	case *ast.Ident:
+		if binding, exist := bindings[typeExpr.Name]; exist {
+			return binding
+		}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: substituteTypeParams(typeExpr.X, bindings)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: typeExpr.Len, Elt: substituteTypeParams(typeExpr.Elt, bindings)}
+	case *ast.MapType:
+		return &ast.MapType{Key: substituteTypeParams(typeExpr.Key, bindings), Value: substituteTypeParams(typeExpr.Value, bindings)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: typeExpr.X, Index: substituteTypeParams(typeExpr.Index, bindings)}
+	case *ast.IndexListExpr:
+		indices := make([]ast.Expr, len(typeExpr.Indices))
+		for index, indexExpr := range typeExpr.Indices {
+			indices[index] = substituteTypeParams(indexExpr, bindings)
+		}
+		return &ast.IndexListExpr{X: typeExpr.X, Indices: indices}
+	case *ast.StructType:
+		fields := make([]*ast.Field, len(typeExpr.Fields.List))
+		for index, field := range typeExpr.Fields.List {
+			fields[index] = &ast.Field{
+				Doc:     field.Doc,
+				Names:   field.Names,
+				Type:    substituteTypeParams(field.Type, bindings),
+				Tag:     field.Tag,
+				Comment: field.Comment,
+			}
+		}
+		return &ast.StructType{Struct: typeExpr.Struct, Fields: &ast.FieldList{List: fields}}
+	}
+
+	return expr
+}
+
+// genericTypeName returns the name of an instantiated generic type expression, e.g. Response[[]models.User].
+func genericTypeName(name string, indices []ast.Expr) (string, error) {
+	args := make([]string, len(indices))
+	for index, indexExpr := range indices {
+		arg, err := typeArgName(indexExpr)
+		if err != nil {
+			return "", err
+		}
+		args[index] = arg
+	}
+
+	return name + "[" + strings.Join(args, ",") + "]", nil
+}
+
+// typeArgName returns the name of a type argument expression, e.g. map[string]models.User.
+func typeArgName(expr ast.Expr) (string, error) {
+	switch typeExpr := expr.(type) {
+	case *ast.ArrayType:
+		elem, err := typeArgName(typeExpr.Elt)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case *ast.MapType:
+		key, err := typeArgName(typeExpr.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := typeArgName(typeExpr.Value)
+		if err != nil {
+			return "", err
+		}
+		return "map[" + key + "]" + value, nil
+	case *ast.InterfaceType:
+		return "interface{}", nil
+	}
+
+	return getFieldType(expr)
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSplitGenericTypeName(t *testing.T) {
+	tests := []struct {
+		typeName string
+		name     string
+		args     []string
+		ok       bool
+	}{
+		{typeName: "Response[models.User]", name: "Response", args: []string{"models.User"}, ok: true},
+		{typeName: "Page[string, map[string][]models.User]", name: "Page", args: []string{"string", "map[string][]models.User"}, ok: true},
+		{typeName: "Response[Page[int,models.User]]", name: "Response", args: []string{"Page[int,models.User]"}, ok: true},
+		{typeName: "map[string]models.User", name: "map[string]models.User", ok: false},
+		{typeName: "[]models.User", name: "[]models.User", ok: false},
+		{typeName: "models.User", name: "models.User", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typeName, func(t *testing.T) {
+			name, args, ok := splitGenericTypeName(test.typeName)
+			if name != test.name || !reflect.DeepEqual(args, test.args) || ok != test.ok {
+				t.Errorf("splitGenericTypeName(%q) = %q, %q, %t, expected %q, %q, %t", test.typeName, name, args, ok, test.name, test.args, test.ok)
+			}
+		})
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	parser, file := newTypesParser(t, "testdata/generics", "testdata/qualified/models", "testdata/qualified/v2/models")
+
+	tests := []struct {
+		name     string
+		generic  string
+		args     []string
+		expected string
+	}{
+		{name: "type of the package", generic: "Response", args: []string{"User"}, expected: "Response-generics_User"},
+		{name: "slice of pointers", generic: "Response", args: []string{"[]*User"}, expected: "Response-array_generics_User"},
+		{name: "type params", generic: "Page", args: []string{"string", "User"}, expected: "Page-string-generics_User"},
+		{name: "primitive", generic: "Response", args: []string{"int64"}, expected: "Response-int64"},
+		{
+			name:     "arguments of the same name from different packages",
+			generic:  "Response",
+			args:     []string{"v2.User"},
+			expected: "Response-github_com_Scterl_go-swagger_parser_testdata_qualified_v2_models_User",
+		},
+	}
+
+	// the instance found first is renamed when the name collides
+	first := parser.packages.instantiate("Response", []string{"models.User"}, file, false)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := parser.packages.instantiate(test.generic, test.args, file, false)
+			if instance == nil {
+				t.Fatalf("instantiate(%s, %q) = nil", test.generic, test.args)
+			}
+			if instance.Name() != test.expected {
+				t.Errorf("instantiate(%s, %q) = %s, expected %s", test.generic, test.args, instance.Name(), test.expected)
+			}
+		})
+	}
+	if expected := "Response-github_com_Scterl_go-swagger_parser_testdata_qualified_models_User"; first.Name() != expected {
+		t.Errorf("instance of the colliding name = %s, expected %s", first.Name(), expected)
+	}
+	if parser.packages.instantiate("Response", []string{"User", "User"}, file, false) != nil {
+		t.Error("instantiate with the wrong number of arguments returns an instance")
+	}
+}
+
+func TestInstanceSchema(t *testing.T) {
+	parser, file := newTypesParser(t, "testdata/generics")
+
+	schema, err := parser.getTypeSchema("generics.Response[generics.User]", file, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"type":"object","properties":{"code":{"type":"integer"},"data":{"$ref":"#/definitions/generics.User"}}}`
+	if string(got) != expected {
+		t.Errorf("schema of generics.Response[generics.User] = %s, expected %s", got, expected)
+	}
+}
@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadMode the package information needed to generate the swagger comments.
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedModule |
+	packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// loadPackages loads the packages in dirs with their syntax and type info, in the order of dirs.
+// The packages are loaded together, the files of a package are checked together and the dependencies are loaded once.
+func loadPackages(fileSet *token.FileSet, dirs []string, buildTags []string) ([]*packages.Package, error) {
+	config := &packages.Config{
+		Mode: loadMode,
+		Fset: fileSet,
+	}
+	if len(buildTags) > 0 {
+		config.BuildFlags = []string{"-tags=" + strings.Join(buildTags, ",")}
+	}
+
+	patterns := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, absDir)
+	}
+
+	pkgs, err := packages.Load(config, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages in dirs %s, %w", strings.Join(dirs, ","), err)
+	}
+
+	pkgDirs := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("load package %s, %s", pkg.PkgPath, pkg.Errors[0])
+		}
+		pkgDirs[filepath.Clean(pkg.Dir)] = pkg
+	}
+
+	results := make([]*packages.Package, 0, len(dirs))
+	for index, dir := range dirs {
+		pkg, exist := pkgDirs[patterns[index]]
+		if !exist {
+			return nil, fmt.Errorf("no package found in dir %s", dir)
+		}
+		results = append(results, pkg)
+	}
+
+	return results, nil
+}
+
+// externalLoadMode the package information needed to parse the type definitions of the external packages.
+const externalLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax
+
+// typedLoadMode the package information needed to read the constants of a package, the dependencies are needed
+// to check the types of the package.
+const typedLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo
+
+// packageLoader loads the packages by import path, each package is loaded once and cached with its dependencies.
+type packageLoader struct {
+	packages map[string]*packages.Package
+	// dirs the cached packages keyed by their dirs
+	dirs map[string]*packages.Package
+	// typedPackages the packages loaded again with the type info keyed by their paths, nil if they failed to load
+	typedPackages map[string]*packages.Package
+}
+
+func newPackageLoader() *packageLoader {
+	return &packageLoader{
+		packages:      make(map[string]*packages.Package),
+		dirs:          make(map[string]*packages.Package),
+		typedPackages: make(map[string]*packages.Package),
+	}
+}
+
+// add caches pkgs and their dependencies.
+func (loader *packageLoader) add(pkgs ...*packages.Package) {
+	packages.Visit(pkgs, func(pkg *packages.Package) bool {
+		if _, exist := loader.packages[pkg.PkgPath]; exist {
+			return false
+		}
+		loader.packages[pkg.PkgPath] = pkg
+		if pkg.Dir != "" {
+			loader.dirs[filepath.Clean(pkg.Dir)] = pkg
+		}
+		return true
+	}, nil)
+}
+
+// cached returns the cached package of pkgPath, which is an import path or the dir of a parsed package, nil if
+// the package is not cached.
+func (loader *packageLoader) cached(pkgPath string) *packages.Package {
+	if pkg, exist := loader.packages[pkgPath]; exist {
+		return pkg
+	}
+	dir, err := filepath.Abs(pkgPath)
+	if err != nil {
+		return nil
+	}
+
+	return loader.dirs[dir]
+}
+
+// load returns the package of importPath, the package is loaded with its dependencies if it is not cached.
+func (loader *packageLoader) load(importPath string) (*packages.Package, error) {
+	if pkg, exist := loader.packages[importPath]; exist {
+		return pkg, nil
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: externalLoadMode}, importPath)
+	if err != nil {
+		return nil, fmt.Errorf("load package %s, %w", importPath, err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("package %s not found", importPath)
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 && len(pkg.Syntax) == 0 {
+		return nil, fmt.Errorf("load package %s, %s", importPath, pkg.Errors[0])
+	}
+	loader.add(pkg)
+
+	return pkg, nil
+}
+
+// typed returns the package of pkgPath with its type info, the package cached without the type info, e.g. an
+// external package or a package restored by the incremental generation, is loaded again, nil if it fails.
+func (loader *packageLoader) typed(pkgPath string) *packages.Package {
+	pattern := pkgPath
+	if pkg := loader.cached(pkgPath); pkg != nil {
+		if pkg.TypesInfo != nil {
+			return pkg
+		}
+		pattern = pkg.PkgPath
+	}
+	if pkg, exist := loader.typedPackages[pattern]; exist {
+		return pkg
+	}
+
+	var typed *packages.Package
+	pkgs, err := packages.Load(&packages.Config{Mode: typedLoadMode}, pattern)
+	switch {
+	case err != nil:
+		log.Printf("[WARNING] failed to load the types of package %s, error: %s\n", pattern, err)
+	case len(pkgs) == 0 || pkgs[0].TypesInfo == nil:
+		log.Printf("[WARNING] failed to load the types of package %s\n", pattern)
+	default:
+		typed = pkgs[0]
+	}
+	loader.typedPackages[pattern] = typed
+
+	return typed
+}
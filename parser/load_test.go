@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPackages(t *testing.T) {
+	tests := []struct {
+		name      string
+		dirs      []string
+		buildTags []string
+		pkgPaths  []string
+		files     []int
+	}{
+		{
+			name:     "in the order of the dirs",
+			dirs:     []string{"testdata/tagged", "testdata/nethttp"},
+			pkgPaths: []string{"github.com/Scterl/go-swagger/parser/testdata/tagged", "github.com/Scterl/go-swagger/parser/testdata/nethttp"},
+			files:    []int{1, 1},
+		},
+		{
+			name:      "with the build tags",
+			dirs:      []string{"testdata/tagged"},
+			buildTags: []string{"admin"},
+			pkgPaths:  []string{"github.com/Scterl/go-swagger/parser/testdata/tagged"},
+			files:     []int{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgs, err := loadPackages(token.NewFileSet(), test.dirs, test.buildTags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pkgs) != len(test.dirs) {
+				t.Fatalf("loadPackages returns %d packages, expected %d", len(pkgs), len(test.dirs))
+			}
+			for index, pkg := range pkgs {
+				if pkg.PkgPath != test.pkgPaths[index] {
+					t.Errorf("package %d = %s, expected %s", index, pkg.PkgPath, test.pkgPaths[index])
+				}
+				if len(pkg.Syntax) != test.files[index] || pkg.TypesInfo == nil {
+					t.Errorf("package %s has %d files checked, expected %d", pkg.PkgPath, len(pkg.Syntax), test.files[index])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadPackagesError(t *testing.T) {
+	if _, err := loadPackages(token.NewFileSet(), []string{filepath.Join("testdata", "missing")}, nil); err == nil {
+		t.Error("loadPackages of a missing dir returns no error")
+	}
+}
+
+func TestPackageLoaderTyped(t *testing.T) {
+	const pkgPath = "github.com/Scterl/go-swagger/parser/testdata/nethttp"
+
+	tests := []struct {
+		name   string
+		loader func(t *testing.T) *packageLoader
+	}{
+		{
+			name:   "not cached",
+			loader: func(t *testing.T) *packageLoader { return newPackageLoader() },
+		},
+		{
+			name: "cached without the type info",
+			loader: func(t *testing.T) *packageLoader {
+				loader := newPackageLoader()
+				if _, err := loader.load(pkgPath); err != nil {
+					t.Fatal(err)
+				}
+				return loader
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			typed := test.loader(t).typed(pkgPath)
+			if typed == nil || typed.TypesInfo == nil {
+				t.Fatalf("package %s is not loaded with the type info", pkgPath)
+			}
+			if typed.Types.Scope().Lookup("User") == nil {
+				t.Errorf("type User of package %s is not checked", pkgPath)
+			}
+		})
+	}
+}
+
+func TestPackageLoader(t *testing.T) {
+	const pkgPath = "github.com/Scterl/go-swagger/parser/testdata/nethttp"
+	loader := newPackageLoader()
+
+	pkg, err := loader.load(pkgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkg.Syntax) != 1 {
+		t.Errorf("package %s has %d files parsed, expected 1", pkgPath, len(pkg.Syntax))
+	}
+	if loaded, err := loader.load(pkgPath); err != nil || loaded != pkg {
+		t.Errorf("package %s is loaded again", pkgPath)
+	}
+	if loader.cached("testdata/nethttp") != pkg {
+		t.Error("package is not cached by its dir")
+	}
+	if loader.cached("net/http") == nil {
+		t.Error("dependency net/http is not cached")
+	}
+
+	if _, err := loader.load("github.com/Scterl/go-swagger/parser/testdata/missing"); err == nil {
+		t.Error("loading a missing package returns no error")
+	}
+}
+
+func TestLoadExternalPackage(t *testing.T) {
+	pkgs := NewPackagesDefinitions()
+	if err := pkgs.loadExternalPackage("github.com/Scterl/go-swagger/parser/testdata/nethttp"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"User", "Error"} {
+		if pkgs.findTypeSpec("github.com/Scterl/go-swagger/parser/testdata/nethttp", name) == nil {
+			t.Errorf("type %s of the external package is not found", name)
+		}
+	}
+}
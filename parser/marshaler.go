@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"log"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// swaggerTypeDirective the comment on a type overriding its schema, the same as the swaggertype tag,
+// e.g. // @swagger:type string or // @swagger:type array,integer.
+const swaggerTypeDirective = "@swagger:type"
+
+// the methods of encoding.TextMarshaler and json.Marshaler, the types implementing them are not serialized as their layouts.
+const (
+	marshalTextMethod = "MarshalText"
+	marshalJSONMethod = "MarshalJSON"
+)
+
+// customSchema returns the schema of a type which is not serialized as its layout, nil if it is. The schema is
+// overridden by the @swagger:type comment on the type, otherwise a type implementing encoding.TextMarshaler is
+// a string. A type implementing only json.Marshaler keeps its layout, its schema is unknown without the comment.
+func (pkgs *PackagesDefinitions) customSchema(typeSpecDef *TypeSpecDef, parseDependency bool) (*spec.Schema, error) {
+	if override := typeOverride(typeSpecDef); override != "" {
+		schema, err := BuildCustomSchema(strings.Split(override, ","))
+		if err != nil {
+			return nil, fmt.Errorf("%s of %s, %w", swaggerTypeDirective, typeSpecDef.FullName(), err)
+		}
+		return schema, nil
+	}
+
+	if pkgs.isMarshaler(typeSpecDef, marshalTextMethod, parseDependency) {
+		return PrimitiveSchema(STRING), nil
+	}
+	if pkgs.isMarshaler(typeSpecDef, marshalJSONMethod, parseDependency) {
+		log.Printf("[WARNING] type %s implements json.Marshaler, its layout is used without the comment %s\n", typeSpecDef.FullName(), swaggerTypeDirective)
+	}
+
+	return nil, nil
+}
+
+// hasCustomSchema reports whether a type has a custom schema, without building the schema.
+func (pkgs *PackagesDefinitions) hasCustomSchema(typeSpecDef *TypeSpecDef) bool {
+	return typeOverride(typeSpecDef) != "" || pkgs.isMarshaler(typeSpecDef, marshalTextMethod, false)
+}
+
+// typeOverride returns the value of the @swagger:type comment on a type, empty if there is none.
+func typeOverride(typeSpecDef *TypeSpecDef) string {
+	comments := []*ast.CommentGroup{typeSpecDef.TypeSpec.Doc, typeSpecDef.TypeSpec.Comment}
+	// the doc of a type declared alone belongs to its declaration
+	for _, decl := range typeSpecDef.File.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE && len(genDecl.Specs) == 1 && genDecl.Specs[0] == typeSpecDef.TypeSpec {
+			comments = append(comments, genDecl.Doc)
+			break
+		}
+	}
+
+	for _, group := range comments {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if value, found := strings.CutPrefix(text, swaggerTypeDirective); found {
+				if fields := strings.Fields(value); len(fields) > 0 {
+					return fields[0]
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// isMarshaler reports whether a type implements the marshaler method, MarshalText or MarshalJSON. The method is found
+// by the type info of the loaded packages, otherwise by the methods declared on the type and its embedded types.
+func (pkgs *PackagesDefinitions) isMarshaler(typeSpecDef *TypeSpecDef, method string, parseDependency bool) bool {
+	if pkgs.loader != nil && typeSpecDef.TypeSpec.TypeParams == nil {
+		if pkg := pkgs.loader.cached(typeSpecDef.PkgPath); pkg != nil && pkg.Types != nil {
+			if obj, ok := pkg.Types.Scope().Lookup(typeSpecDef.Name()).(*types.TypeName); ok {
+				return implementsMarshaler(obj.Type(), method)
+			}
+		}
+	}
+
+	return pkgs.declaresMarshaler(typeSpecDef, method, parseDependency, make(map[*TypeSpecDef]bool))
+}
+
+// declaresMarshaler reports whether the marshaler method is declared on a type, or promoted by its embedded fields.
+func (pkgs *PackagesDefinitions) declaresMarshaler(typeSpecDef *TypeSpecDef, method string, parseDependency bool, visited map[*TypeSpecDef]bool) bool {
+	if visited[typeSpecDef] {
+		return false
+	}
+	visited[typeSpecDef] = true
+
+	files := []*ast.File{typeSpecDef.File}
+	if definitions := pkgs.packages[typeSpecDef.PkgPath]; definitions != nil {
+		for _, file := range definitions.Files {
+			files = append(files, file)
+		}
+	}
+	if pkgs.loader != nil {
+		if pkg := pkgs.loader.cached(typeSpecDef.PkgPath); pkg != nil {
+			files = append(files, pkg.Syntax...)
+		}
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 || !isMarshalerMethod(funcDecl, method) {
+				continue
+			}
+			if receiverTypeName(funcDecl.Recv.List[0].Type) == typeSpecDef.Name() {
+				return true
+			}
+		}
+	}
+
+	structType, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+	for _, field := range structType.Fields.List {
+		if len(field.Names) > 0 {
+			continue
+		}
+		typeName, err := getFieldType(field.Type)
+		if err != nil {
+			continue
+		}
+		embedded := pkgs.FindTypeSpec(typeName, typeSpecDef.File, parseDependency)
+		if embedded != nil && pkgs.declaresMarshaler(embedded, method, parseDependency, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func implementsMarshaler(typ types.Type, method string) bool {
+	// the methods of the pointer receivers are used by encoding/json for the addressable values
+	selection := types.NewMethodSet(types.NewPointer(typ)).Lookup(nil, method)
+	if selection == nil {
+		return false
+	}
+	signature, ok := selection.Type().(*types.Signature)
+
+	return ok && signature.Params().Len() == 0 && signature.Results().Len() == 2
+}
+
+func isMarshalerMethod(funcDecl *ast.FuncDecl, method string) bool {
+	return funcDecl.Name.Name == method && funcDecl.Type.Params.NumFields() == 0 && funcDecl.Type.Results.NumFields() == 2
+}
+
+// receiverTypeName returns the name of the type of a receiver, e.g. Response for *Response[T].
+func receiverTypeName(expr ast.Expr) string {
+	switch typeExpr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.ParenExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.Ident:
+		return typeExpr.Name
+	}
+
+	return ""
+}
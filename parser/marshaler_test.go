@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalerSchema(t *testing.T) {
+	schemas := parseTypeSchemas(t, "testdata/marshalers", "Text", "Both", "JSONOnly", "Coord", "Embedded")
+
+	tests := []struct {
+		name     string
+		typeName string
+		expected string
+	}{
+		{name: "text marshaler", typeName: "Text", expected: `{"type":"string"}`},
+		{name: "text and json marshaler", typeName: "Both", expected: `{"type":"string"}`},
+		{name: "json marshaler keeps the layout", typeName: "JSONOnly", expected: `{"type":"object","properties":{"value":{"type":"string"}}}`},
+		{name: "swagger type comment", typeName: "Coord", expected: `{"type":"array","items":{"type":"number"}}`},
+		{name: "promoted text marshaler", typeName: "Embedded", expected: `{"type":"string"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(schemas[test.typeName])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.expected {
+				t.Errorf("schema of %s = %s, expected %s", test.typeName, got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,694 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/swag"
+)
+
+const (
+	// OpenAPIVersion2 swagger 2.0.
+	OpenAPIVersion2 = "2.0"
+	// OpenAPIVersion30 openapi 3.0.
+	OpenAPIVersion30 = "3.0.3"
+	// OpenAPIVersion31 openapi 3.1.
+	OpenAPIVersion31 = "3.1.0"
+
+	definitionsRefPrefix = "#/definitions/"
+	componentsRefPrefix  = "#/components/schemas/"
+)
+
+// OpenAPI the root document object of an OpenAPI 3.x specification.
+type OpenAPI struct {
+	OpenAPI      string                      `json:"openapi"`
+	Info         *spec.Info                  `json:"info,omitempty"`
+	Servers      []OpenAPIServer             `json:"servers,omitempty"`
+	Paths        map[string]*OpenAPIPathItem `json:"paths"`
+	Components   *OpenAPIComponents          `json:"components,omitempty"`
+	Security     []map[string][]string       `json:"security,omitempty"`
+	Tags         []spec.Tag                  `json:"tags,omitempty"`
+	ExternalDocs *spec.ExternalDocumentation `json:"externalDocs,omitempty"`
+
+	Extensions spec.Extensions `json:"-"`
+}
+
+// OpenAPIServer an object representing a server.
+type OpenAPIServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIComponents holds the reusable objects of the specification.
+type OpenAPIComponents struct {
+	Schemas         map[string]spec.Schema            `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// OpenAPIPathItem describes the operations available on a single path.
+type OpenAPIPathItem struct {
+	Get     *OpenAPIOperation `json:"get,omitempty"`
+	Put     *OpenAPIOperation `json:"put,omitempty"`
+	Post    *OpenAPIOperation `json:"post,omitempty"`
+	Delete  *OpenAPIOperation `json:"delete,omitempty"`
+	Options *OpenAPIOperation `json:"options,omitempty"`
+	Head    *OpenAPIOperation `json:"head,omitempty"`
+	Patch   *OpenAPIOperation `json:"patch,omitempty"`
+}
+
+// OpenAPIOperation describes a single API operation on a path.
+type OpenAPIOperation struct {
+	Tags         []string                    `json:"tags,omitempty"`
+	Summary      string                      `json:"summary,omitempty"`
+	Description  string                      `json:"description,omitempty"`
+	ExternalDocs *spec.ExternalDocumentation `json:"externalDocs,omitempty"`
+	OperationID  string                      `json:"operationId,omitempty"`
+	Parameters   []OpenAPIParameter          `json:"parameters,omitempty"`
+	RequestBody  *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses    map[string]*OpenAPIResponse `json:"responses"`
+	Deprecated   bool                        `json:"deprecated,omitempty"`
+	Security     []map[string][]string       `json:"security,omitempty"`
+
+	Extensions spec.Extensions `json:"-"`
+}
+
+// OpenAPIParameter describes a single operation parameter.
+type OpenAPIParameter struct {
+	Name            string       `json:"name"`
+	In              string       `json:"in"`
+	Description     string       `json:"description,omitempty"`
+	Required        bool         `json:"required,omitempty"`
+	AllowEmptyValue bool         `json:"allowEmptyValue,omitempty"`
+	Style           string       `json:"style,omitempty"`
+	Explode         *bool        `json:"explode,omitempty"`
+	Schema          *spec.Schema `json:"schema,omitempty"`
+	Example         interface{}  `json:"example,omitempty"`
+
+	Extensions spec.Extensions `json:"-"`
+}
+
+// OpenAPIRequestBody describes a single request body.
+type OpenAPIRequestBody struct {
+	Description string                      `json:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType provides schema and examples for a media type.
+type OpenAPIMediaType struct {
+	Schema  *spec.Schema `json:"schema,omitempty"`
+	Example interface{}  `json:"example,omitempty"`
+}
+
+// OpenAPIResponse describes a single response from an API operation.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Headers     map[string]OpenAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIHeader describes a single response header.
+type OpenAPIHeader struct {
+	Description string       `json:"description,omitempty"`
+	Schema      *spec.Schema `json:"schema,omitempty"`
+}
+
+// OpenAPISecurityScheme defines a security scheme that can be used by the operations.
+type OpenAPISecurityScheme struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	In          string             `json:"in,omitempty"`
+	Scheme      string             `json:"scheme,omitempty"`
+	Flows       *OpenAPIOAuthFlows `json:"flows,omitempty"`
+
+	Extensions spec.Extensions `json:"-"`
+}
+
+// OpenAPIOAuthFlows allows configuration of the supported OAuth flows.
+type OpenAPIOAuthFlows struct {
+	Implicit          *OpenAPIOAuthFlow `json:"implicit,omitempty"`
+	Password          *OpenAPIOAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OpenAPIOAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OpenAPIOAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OpenAPIOAuthFlow configuration details for a supported OAuth flow.
+type OpenAPIOAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// MarshalJSON marshal OpenAPI with its vendor extensions.
+func (o OpenAPI) MarshalJSON() ([]byte, error) {
+	type alias OpenAPI
+
+	return marshalWithExtensions(alias(o), o.Extensions)
+}
+
+// MarshalJSON marshal OpenAPIOperation with its vendor extensions.
+func (o OpenAPIOperation) MarshalJSON() ([]byte, error) {
+	type alias OpenAPIOperation
+
+	return marshalWithExtensions(alias(o), o.Extensions)
+}
+
+// MarshalJSON marshal OpenAPIParameter with its vendor extensions.
+func (p OpenAPIParameter) MarshalJSON() ([]byte, error) {
+	type alias OpenAPIParameter
+
+	return marshalWithExtensions(alias(p), p.Extensions)
+}
+
+// MarshalJSON marshal OpenAPISecurityScheme with its vendor extensions.
+func (s OpenAPISecurityScheme) MarshalJSON() ([]byte, error) {
+	type alias OpenAPISecurityScheme
+
+	return marshalWithExtensions(alias(s), s.Extensions)
+}
+
+func marshalWithExtensions(value interface{}, extensions spec.Extensions) ([]byte, error) {
+	b1, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(extensions) == 0 {
+		return b1, nil
+	}
+
+	b2, err := json.Marshal(extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	return swag.ConcatJSON(b1, b2), nil
+}
+
+// SetOpenAPIVersion sets the version of the generated document, 2.0, 3.0 or 3.1, the unknown versions are 2.0.
+func SetOpenAPIVersion(version string) func(*Parser) {
+	return func(p *Parser) {
+		normalized, err := normalizeOpenAPIVersion(version)
+		if err != nil {
+			log.Printf("[WARNING] %s, generate OpenAPI %s\n", err, normalized)
+		}
+		p.openAPIVersion = normalized
+	}
+}
+
+// normalizeOpenAPIVersion returns the version of the document for version, 2.0 with an error if it is not supported.
+func normalizeOpenAPIVersion(version string) (string, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v") {
+	case "2", "2.0":
+		return OpenAPIVersion2, nil
+	case "3", "3.0", "3.0.0", "3.0.1", "3.0.2", "3.0.3":
+		return OpenAPIVersion30, nil
+	case "3.1", "3.1.0":
+		return OpenAPIVersion31, nil
+	}
+
+	return OpenAPIVersion2, fmt.Errorf("unsupported OpenAPI version %q, expected 2.0, 3.0 or 3.1", version)
+}
+
+// IsOpenAPI3 whether the parser generates an OpenAPI 3.x document.
+func (parser *Parser) IsOpenAPI3() bool {
+	return parser.openAPIVersion == OpenAPIVersion30 || parser.openAPIVersion == OpenAPIVersion31
+}
+
+// GetOpenAPI returns the OpenAPI 3.x document converted from the parsed swagger.
+func (parser *Parser) GetOpenAPI() *OpenAPI {
+	version := parser.openAPIVersion
+	if version != OpenAPIVersion31 {
+		version = OpenAPIVersion30
+	}
+
+	return ConvertToOpenAPI(parser.swagger, version)
+}
+
+// GetDocument returns the document of the configured version, *spec.Swagger or *OpenAPI.
+func (parser *Parser) GetDocument() interface{} {
+	if parser.IsOpenAPI3() {
+		return parser.GetOpenAPI()
+	}
+
+	return parser.GetSwagger()
+}
+
+// ConvertToOpenAPI converts a swagger 2.0 document into an OpenAPI document of the given 3.x version.
+func ConvertToOpenAPI(swagger *spec.Swagger, version string) *OpenAPI {
+	converter := openAPIConverter{
+		swagger: swagger,
+		version: version,
+	}
+
+	return converter.convert()
+}
+
+type openAPIConverter struct {
+	swagger *spec.Swagger
+	version string
+}
+
+func (c *openAPIConverter) convert() *OpenAPI {
+	doc := &OpenAPI{
+		OpenAPI:      c.version,
+		Info:         c.swagger.Info,
+		Servers:      c.servers(),
+		Paths:        make(map[string]*OpenAPIPathItem),
+		Security:     c.swagger.Security,
+		Tags:         c.swagger.Tags,
+		ExternalDocs: c.swagger.ExternalDocs,
+		Extensions:   c.swagger.Extensions,
+	}
+
+	components := &OpenAPIComponents{}
+	if len(c.swagger.Definitions) > 0 {
+		components.Schemas = make(map[string]spec.Schema, len(c.swagger.Definitions))
+		for name, schema := range c.swagger.Definitions {
+			schema := schema
+			components.Schemas[name] = *c.schema(&schema)
+		}
+	}
+	if len(c.swagger.SecurityDefinitions) > 0 {
+		components.SecuritySchemes = make(map[string]*OpenAPISecurityScheme, len(c.swagger.SecurityDefinitions))
+		for name, scheme := range c.swagger.SecurityDefinitions {
+			components.SecuritySchemes[name] = c.securityScheme(scheme)
+		}
+	}
+	if len(components.Schemas) > 0 || len(components.SecuritySchemes) > 0 {
+		doc.Components = components
+	}
+
+	if c.swagger.Paths != nil {
+		for path, item := range c.swagger.Paths.Paths {
+			doc.Paths[path] = &OpenAPIPathItem{
+				Get:     c.operation(item.Get, item.Parameters),
+				Put:     c.operation(item.Put, item.Parameters),
+				Post:    c.operation(item.Post, item.Parameters),
+				Delete:  c.operation(item.Delete, item.Parameters),
+				Options: c.operation(item.Options, item.Parameters),
+				Head:    c.operation(item.Head, item.Parameters),
+				Patch:   c.operation(item.Patch, item.Parameters),
+			}
+		}
+	}
+
+	return doc
+}
+
+func (c *openAPIConverter) servers() []OpenAPIServer {
+	basePath := c.swagger.BasePath
+	if c.swagger.Host == "" {
+		if basePath == "" {
+			return nil
+		}
+
+		return []OpenAPIServer{{URL: basePath}}
+	}
+
+	if len(c.swagger.Schemes) == 0 {
+		return []OpenAPIServer{{URL: "//" + c.swagger.Host + basePath}}
+	}
+
+	servers := make([]OpenAPIServer, 0, len(c.swagger.Schemes))
+	for _, scheme := range c.swagger.Schemes {
+		servers = append(servers, OpenAPIServer{URL: scheme + "://" + c.swagger.Host + basePath})
+	}
+
+	return servers
+}
+
+func (c *openAPIConverter) securityScheme(scheme *spec.SecurityScheme) *OpenAPISecurityScheme {
+	result := &OpenAPISecurityScheme{
+		Type:        scheme.Type,
+		Description: scheme.Description,
+		Extensions:  scheme.Extensions,
+	}
+
+	switch scheme.Type {
+	case "basic":
+		result.Type = "http"
+		result.Scheme = "basic"
+	case "apiKey":
+		result.Name = scheme.Name
+		result.In = scheme.In
+	case "oauth2":
+		flow := &OpenAPIOAuthFlow{
+			AuthorizationURL: scheme.AuthorizationURL,
+			TokenURL:         scheme.TokenURL,
+			Scopes:           scheme.Scopes,
+		}
+		if flow.Scopes == nil {
+			flow.Scopes = map[string]string{}
+		}
+
+		result.Flows = &OpenAPIOAuthFlows{}
+		switch scheme.Flow {
+		case "implicit":
+			flow.TokenURL = ""
+			result.Flows.Implicit = flow
+		case "password":
+			flow.AuthorizationURL = ""
+			result.Flows.Password = flow
+		case "application":
+			flow.AuthorizationURL = ""
+			result.Flows.ClientCredentials = flow
+		case "accessCode":
+			result.Flows.AuthorizationCode = flow
+		}
+	}
+
+	return result
+}
+
+func (c *openAPIConverter) operation(operation *spec.Operation, pathParams []spec.Parameter) *OpenAPIOperation {
+	if operation == nil {
+		return nil
+	}
+
+	result := &OpenAPIOperation{
+		Tags:         operation.Tags,
+		Summary:      operation.Summary,
+		Description:  operation.Description,
+		ExternalDocs: operation.ExternalDocs,
+		OperationID:  operation.ID,
+		Responses:    make(map[string]*OpenAPIResponse),
+		Deprecated:   operation.Deprecated,
+		Security:     operation.Security,
+		Extensions:   make(spec.Extensions, len(operation.Extensions)),
+	}
+	for key, value := range operation.Extensions {
+		if key != CookieParamsExtension {
+			result.Extensions[key] = value
+		}
+	}
+
+	consumes := operation.Consumes
+	if len(consumes) == 0 {
+		consumes = c.swagger.Consumes
+	}
+	produces := operation.Produces
+	if len(produces) == 0 {
+		produces = c.swagger.Produces
+	}
+	if len(produces) == 0 {
+		produces = []string{mimeTypeAliases["json"]}
+	}
+
+	formParams := make([]spec.Parameter, 0)
+	params := append(append([]spec.Parameter{}, pathParams...), operation.Parameters...)
+	params = append(params, cookieParameters(operation)...)
+	for _, param := range params {
+		switch param.In {
+		case "body":
+			mimeTypes := consumes
+			if len(mimeTypes) == 0 {
+				mimeTypes = []string{mimeTypeAliases["json"]}
+			}
+
+			result.RequestBody = &OpenAPIRequestBody{
+				Description: param.Description,
+				Required:    param.Required,
+				Content:     c.content(mimeTypes, c.schema(param.Schema)),
+			}
+		case "formData":
+			formParams = append(formParams, param)
+		default:
+			result.Parameters = append(result.Parameters, c.parameter(param))
+		}
+	}
+
+	if len(formParams) > 0 {
+		result.RequestBody = c.formRequestBody(formParams, consumes)
+	}
+
+	if operation.Responses != nil {
+		if operation.Responses.Default != nil {
+			result.Responses["default"] = c.response(operation.Responses.Default, produces, "")
+		}
+		for code, response := range operation.Responses.StatusCodeResponses {
+			response := response
+			result.Responses[strconv.Itoa(code)] = c.response(&response, produces, http.StatusText(code))
+		}
+	}
+
+	return result
+}
+
+func (c *openAPIConverter) parameter(param spec.Parameter) OpenAPIParameter {
+	result := OpenAPIParameter{
+		Name:            param.Name,
+		In:              param.In,
+		Description:     param.Description,
+		Required:        param.Required || param.In == "path",
+		AllowEmptyValue: param.AllowEmptyValue,
+		Schema:          c.simpleSchema(param.SimpleSchema, param.CommonValidations),
+		Example:         param.Example,
+		Extensions:      param.Extensions,
+	}
+
+	if param.Type == ARRAY {
+		explode := false
+		switch param.CollectionFormat {
+		case "multi":
+			explode = true
+			result.Style = "form"
+		case "pipes":
+			result.Style = "pipeDelimited"
+		case "ssv":
+			result.Style = "spaceDelimited"
+		default:
+			if param.In == "query" {
+				result.Style = "form"
+			}
+		}
+		result.Explode = &explode
+	}
+
+	return result
+}
+
+func (c *openAPIConverter) formRequestBody(params []spec.Parameter, consumes []string) *OpenAPIRequestBody {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:       []string{OBJECT},
+			Properties: make(map[string]spec.Schema),
+		},
+	}
+
+	mimeType := mimeTypeAliases["x-www-form-urlencoded"]
+	for _, param := range params {
+		if param.Type == "file" {
+			mimeType = mimeTypeAliases["mpfd"]
+		}
+		prop := c.simpleSchema(param.SimpleSchema, param.CommonValidations)
+		prop.Description = param.Description
+		schema.Properties[param.Name] = *prop
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	for _, consume := range consumes {
+		if consume == mimeTypeAliases["mpfd"] {
+			mimeType = consume
+		}
+	}
+	sort.Strings(schema.Required)
+
+	return &OpenAPIRequestBody{
+		Content: map[string]OpenAPIMediaType{
+			mimeType: {Schema: schema},
+		},
+	}
+}
+
+func (c *openAPIConverter) response(response *spec.Response, produces []string, description string) *OpenAPIResponse {
+	result := &OpenAPIResponse{
+		Description: response.Description,
+	}
+	if result.Description == "" {
+		result.Description = description
+	}
+
+	if response.Schema != nil {
+		result.Content = c.content(produces, c.schema(response.Schema))
+	}
+
+	if len(response.Headers) > 0 {
+		result.Headers = make(map[string]OpenAPIHeader, len(response.Headers))
+		for name, header := range response.Headers {
+			result.Headers[name] = OpenAPIHeader{
+				Description: header.Description,
+				Schema:      c.simpleSchema(header.SimpleSchema, header.CommonValidations),
+			}
+		}
+	}
+
+	return result
+}
+
+func (c *openAPIConverter) content(mimeTypes []string, schema *spec.Schema) map[string]OpenAPIMediaType {
+	content := make(map[string]OpenAPIMediaType, len(mimeTypes))
+	for _, mimeType := range mimeTypes {
+		content[mimeType] = OpenAPIMediaType{Schema: schema}
+	}
+
+	return content
+}
+
+// simpleSchema converts the simple schema of a non body parameter or header to a schema.
+func (c *openAPIConverter) simpleSchema(simple spec.SimpleSchema, validations spec.CommonValidations) *spec.Schema {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Nullable:         simple.Nullable,
+			Format:           simple.Format,
+			Default:          simple.Default,
+			Maximum:          validations.Maximum,
+			ExclusiveMaximum: validations.ExclusiveMaximum,
+			Minimum:          validations.Minimum,
+			ExclusiveMinimum: validations.ExclusiveMinimum,
+			MaxLength:        validations.MaxLength,
+			MinLength:        validations.MinLength,
+			Pattern:          validations.Pattern,
+			MaxItems:         validations.MaxItems,
+			MinItems:         validations.MinItems,
+			UniqueItems:      validations.UniqueItems,
+			MultipleOf:       validations.MultipleOf,
+			Enum:             validations.Enum,
+		},
+	}
+	if simple.Type != "" {
+		schema.Type = []string{simple.Type}
+	}
+	if simple.Type == "file" {
+		schema.Type = []string{STRING}
+		schema.Format = "binary"
+	}
+	if simple.Items != nil {
+		schema.Items = &spec.SchemaOrArray{
+			Schema: c.simpleSchema(simple.Items.SimpleSchema, simple.Items.CommonValidations),
+		}
+	}
+
+	return c.schema(schema)
+}
+
+// schema returns a converted copy of the swagger schema.
+func (c *openAPIConverter) schema(schema *spec.Schema) *spec.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	// deep copy, the definitions of the swagger document must be kept as they are
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return schema
+	}
+	result := &spec.Schema{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return schema
+	}
+
+	c.walkSchema(result)
+
+	return result
+}
+
+func (c *openAPIConverter) walkSchema(schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+
+	if ref := schema.Ref.String(); strings.HasPrefix(ref, definitionsRefPrefix) {
+		schema.Ref = spec.MustCreateRef(componentsRefPrefix + strings.TrimPrefix(ref, definitionsRefPrefix))
+	}
+
+	if nullable, ok := schema.Extensions.GetBool("x-nullable"); ok {
+		delete(schema.Extensions, "x-nullable")
+		schema.Nullable = schema.Nullable || nullable
+	}
+
+	if len(schema.Type) > 0 && schema.Type[0] == "file" {
+		schema.Type = []string{STRING}
+		schema.Format = "binary"
+	}
+
+	if schema.Nullable {
+		switch {
+		case schema.Ref.String() != "":
+			// siblings of $ref are ignored, wrap the reference to keep it nullable
+			ref := spec.Schema{SchemaProps: spec.SchemaProps{Ref: schema.Ref}}
+			schema.Ref = spec.Ref{}
+			if c.version == OpenAPIVersion31 {
+				schema.Nullable = false
+				schema.OneOf = append(schema.OneOf, ref, *PrimitiveSchema("null"))
+			} else {
+				schema.AllOf = append(schema.AllOf, ref)
+			}
+		case c.version == OpenAPIVersion31 && len(schema.Type) > 0:
+			// 3.1 follows json schema, nullable is expressed by the "null" type
+			schema.Nullable = false
+			schema.Type = append(schema.Type, "null")
+		}
+	}
+
+	if c.version == OpenAPIVersion31 {
+		convertExclusiveBounds(schema)
+	}
+
+	for name, prop := range schema.Properties {
+		prop := prop
+		c.walkSchema(&prop)
+		schema.Properties[name] = prop
+	}
+
+	if schema.Items != nil {
+		c.walkSchema(schema.Items.Schema)
+		for i := range schema.Items.Schemas {
+			c.walkSchema(&schema.Items.Schemas[i])
+		}
+	}
+
+	if schema.AdditionalProperties != nil {
+		c.walkSchema(schema.AdditionalProperties.Schema)
+	}
+
+	for i := range schema.AllOf {
+		c.walkSchema(&schema.AllOf[i])
+	}
+	for i := range schema.AnyOf {
+		c.walkSchema(&schema.AnyOf[i])
+	}
+	for i := range schema.OneOf {
+		c.walkSchema(&schema.OneOf[i])
+	}
+	c.walkSchema(schema.Not)
+}
+
+// convertExclusiveBounds converts the exclusive bounds of swagger 2.0, the flags of minimum and maximum, to the
+// numbers of 3.1, e.g. minimum 0 with exclusiveMinimum true to exclusiveMinimum 0.
+func convertExclusiveBounds(schema *spec.Schema) {
+	if schema.ExclusiveMinimum && schema.Minimum != nil {
+		setExtraProp(schema, "exclusiveMinimum", *schema.Minimum)
+		schema.Minimum = nil
+	}
+	schema.ExclusiveMinimum = false
+	if schema.ExclusiveMaximum && schema.Maximum != nil {
+		setExtraProp(schema, "exclusiveMaximum", *schema.Maximum)
+		schema.Maximum = nil
+	}
+	schema.ExclusiveMaximum = false
+}
+
+func setExtraProp(schema *spec.Schema, name string, value interface{}) {
+	if schema.ExtraProps == nil {
+		schema.ExtraProps = make(map[string]interface{})
+	}
+	schema.ExtraProps[name] = value
+}
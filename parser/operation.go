@@ -4,18 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
-	goparser "go/parser"
 	"go/token"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/go-openapi/spec"
-	"golang.org/x/tools/go/loader"
 )
 
 // RouteProperties describes HTTP properties of a single router comment.
@@ -234,8 +231,10 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 	switch paramType {
 	case "path", "header":
 		switch objectType {
-		case ARRAY, OBJECT:
+		case ARRAY:
 			return fmt.Errorf("%s is not supported type for %s", refType, paramType)
+		case OBJECT:
+			return operation.parseObjectParams(paramType, refType, astFile)
 		}
 	case "query", "formData":
 		switch objectType {
@@ -253,64 +252,11 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 				},
 			}
 		case OBJECT:
-			schema, err := operation.parser.getTypeSchema(refType, astFile, true)
-			if err != nil {
-				return err
-			}
-			if len(schema.Properties) == 0 {
-				return nil
-			}
-
-			items := schema.Properties.ToOrderedSchemaItems()
-			for _, item := range items {
-				name := item.Name
-				prop := item.Schema
-				if len(prop.Type) == 0 {
-					continue
-				}
-				switch {
-				case prop.Type[0] == ARRAY &&
-					prop.Items.Schema != nil &&
-					len(prop.Items.Schema.Type) > 0 &&
-					IsSimplePrimitiveType(prop.Items.Schema.Type[0]):
-					param = createParameter(paramType, prop.Description, name, prop.Type[0], findInSlice(schema.Required, name))
-					param.SimpleSchema.Type = prop.Type[0]
-					if operation.parser != nil && operation.parser.collectionFormatInQuery != "" && param.CollectionFormat == "" {
-						param.CollectionFormat = TransToValidCollectionFormat(operation.parser.collectionFormatInQuery)
-					}
-					param.SimpleSchema.Items = &spec.Items{
-						SimpleSchema: spec.SimpleSchema{
-							Type: prop.Items.Schema.Type[0],
-						},
-					}
-				case IsSimplePrimitiveType(prop.Type[0]):
-					param = createParameter(paramType, prop.Description, name, prop.Type[0], findInSlice(schema.Required, name))
-				default:
-					operation.parser.debug.Printf("skip field [%s] in %s is not supported type for %s", name, refType, paramType)
-
-					continue
-				}
-				param.Nullable = prop.Nullable
-				param.Format = prop.Format
-				param.Default = prop.Default
-				param.Example = prop.Example
-				param.Extensions = prop.Extensions
-				param.CommonValidations.Maximum = prop.Maximum
-				param.CommonValidations.Minimum = prop.Minimum
-				param.CommonValidations.ExclusiveMaximum = prop.ExclusiveMaximum
-				param.CommonValidations.ExclusiveMinimum = prop.ExclusiveMinimum
-				param.CommonValidations.MaxLength = prop.MaxLength
-				param.CommonValidations.MinLength = prop.MinLength
-				param.CommonValidations.Pattern = prop.Pattern
-				param.CommonValidations.MaxItems = prop.MaxItems
-				param.CommonValidations.MinItems = prop.MinItems
-				param.CommonValidations.UniqueItems = prop.UniqueItems
-				param.CommonValidations.MultipleOf = prop.MultipleOf
-				param.CommonValidations.Enum = prop.Enum
-				operation.Operation.Parameters = append(operation.Operation.Parameters, param)
-			}
-
-			return nil
+			return operation.parseObjectParams(paramType, refType, astFile)
+		}
+	case "cookie":
+		if objectType != PRIMITIVE {
+			return fmt.Errorf("%s is not supported type for %s", refType, paramType)
 		}
 	case "body":
 		if objectType == PRIMITIVE {
@@ -330,11 +276,145 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 	if err != nil {
 		return err
 	}
+	if paramType != "body" && operation.hasParameter(name, paramType) {
+		return nil
+	}
+	if paramType == "cookie" {
+		operation.addCookieParameter(param)
+		return nil
+	}
 	operation.Operation.Parameters = append(operation.Operation.Parameters, param)
 
 	return nil
 }
 
+// paramTags the struct tags naming the fields bound by gin from each kind of params.
+var paramTags = map[string]string{
+	"query":    "form",
+	"formData": "form",
+	"path":     "uri",
+	"header":   "header",
+}
+
+// parseObjectParams expands the fields of a struct to the params of paramType, e.g. @Param q query models.Query false "q".
+// The params are named by the tags form, uri or header if the fields have them, the params declared already are skipped.
+func (operation *Operation) parseObjectParams(paramType, refType string, astFile *ast.File) error {
+	schema, err := operation.parser.getTypeSchema(refType, astFile, false)
+	if err != nil {
+		return err
+	}
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+
+	names := operation.parser.paramFieldNames(refType, astFile, paramTags[paramType])
+
+	items := schema.Properties.ToOrderedSchemaItems()
+	for _, item := range items {
+		name := item.Name
+		prop := item.Schema
+		if len(prop.Type) == 0 {
+			continue
+		}
+		if tagName, exist := names[item.Name]; exist {
+			if tagName == "" {
+				continue
+			}
+			name = tagName
+		}
+		if operation.hasParameter(name, paramType) {
+			continue
+		}
+		required := findInSlice(schema.Required, item.Name) || paramType == "path"
+
+		var param spec.Parameter
+		switch {
+		case prop.Type[0] == ARRAY &&
+			prop.Items.Schema != nil &&
+			len(prop.Items.Schema.Type) > 0 &&
+			IsSimplePrimitiveType(prop.Items.Schema.Type[0]):
+			param = createParameter(paramType, prop.Description, name, prop.Type[0], required)
+			param.SimpleSchema.Type = prop.Type[0]
+			if operation.parser != nil && operation.parser.collectionFormatInQuery != "" && param.CollectionFormat == "" {
+				param.CollectionFormat = TransToValidCollectionFormat(operation.parser.collectionFormatInQuery)
+			}
+			items := prop.Items.Schema
+			param.SimpleSchema.Items = &spec.Items{
+				SimpleSchema: spec.SimpleSchema{
+					Type:   items.Type[0],
+					Format: items.Format,
+				},
+				CommonValidations: spec.CommonValidations{
+					Maximum:          items.Maximum,
+					Minimum:          items.Minimum,
+					ExclusiveMaximum: items.ExclusiveMaximum,
+					ExclusiveMinimum: items.ExclusiveMinimum,
+					MaxLength:        items.MaxLength,
+					MinLength:        items.MinLength,
+					Pattern:          items.Pattern,
+					Enum:             items.Enum,
+				},
+			}
+		case IsSimplePrimitiveType(prop.Type[0]):
+			param = createParameter(paramType, prop.Description, name, prop.Type[0], required)
+		default:
+			operation.parser.debug.Printf("skip field [%s] in %s is not supported type for %s", name, refType, paramType)
+
+			continue
+		}
+		param.Nullable = prop.Nullable
+		param.Format = prop.Format
+		param.Default = prop.Default
+		param.Example = prop.Example
+		param.Extensions = prop.Extensions
+		param.CommonValidations.Maximum = prop.Maximum
+		param.CommonValidations.Minimum = prop.Minimum
+		param.CommonValidations.ExclusiveMaximum = prop.ExclusiveMaximum
+		param.CommonValidations.ExclusiveMinimum = prop.ExclusiveMinimum
+		param.CommonValidations.MaxLength = prop.MaxLength
+		param.CommonValidations.MinLength = prop.MinLength
+		param.CommonValidations.Pattern = prop.Pattern
+		param.CommonValidations.MaxItems = prop.MaxItems
+		param.CommonValidations.MinItems = prop.MinItems
+		param.CommonValidations.UniqueItems = prop.UniqueItems
+		param.CommonValidations.MultipleOf = prop.MultipleOf
+		param.CommonValidations.Enum = prop.Enum
+		operation.Operation.Parameters = append(operation.Operation.Parameters, param)
+	}
+
+	return nil
+}
+
+// CookieParamsExtension the extension of an operation keeping its cookie params, swagger 2.0 has no cookie params,
+// they are converted to the params in cookie of openapi 3.
+const CookieParamsExtension = "x-cookie-params"
+
+// addCookieParameter adds a cookie param to the extension of the operation.
+func (operation *Operation) addCookieParameter(param spec.Parameter) {
+	if operation.Extensions == nil {
+		operation.Extensions = spec.Extensions{}
+	}
+	params, _ := operation.Extensions[CookieParamsExtension].([]spec.Parameter)
+	operation.Extensions[CookieParamsExtension] = append(params, param)
+}
+
+// cookieParameters returns the cookie params kept in the extension of an operation.
+func cookieParameters(operation *spec.Operation) []spec.Parameter {
+	params, _ := operation.Extensions[CookieParamsExtension].([]spec.Parameter)
+	return params
+}
+
+// hasParameter reports whether the operation has the param already, e.g. a path param read by ctx.Param and bound by ctx.ShouldBindUri.
+func (operation *Operation) hasParameter(name, paramType string) bool {
+	for _, param := range append(cookieParameters(&operation.Operation), operation.Operation.Parameters...) {
+		if param.Name == name && param.In == paramType {
+			return true
+		}
+	}
+
+	return false
+}
+
 var regexAttributes = map[string]*regexp.Regexp{
 	// for Enums(A, B)
 	"enums": regexp.MustCompile(`(?i)\s+enums\(.*\)`),
@@ -614,46 +694,15 @@ func (operation *Operation) ParseSecurityComment(commentLine string) error {
 }
 
 // findTypeDef attempts to find the *ast.TypeSpec for a specific type given the
-// type's name and the package's import path.
-// TODO: improve finding external pkg
-func findTypeDef(importPath, typeName string) (*ast.TypeSpec, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	conf := loader.Config{
-		ParserMode: goparser.SpuriousErrors,
-		Cwd:        cwd,
-	}
-
-	conf.Import(importPath)
-
-	lprog, err := conf.Load()
+// type's name and the package's import path, the package is loaded by loader.
+func findTypeDef(loader *packageLoader, importPath, typeName string) (*ast.TypeSpec, error) {
+	pkgInfo, err := loader.load(importPath)
 	if err != nil {
 		return nil, err
 	}
 
-	// If the pkg is vendored, the actual pkg path is going to resemble
-	// something like "{importPath}/vendor/{importPath}"
-	for k := range lprog.AllPackages {
-		realPkgPath := k.Path()
-
-		if strings.Contains(realPkgPath, "vendor/"+importPath) {
-			importPath = realPkgPath
-		}
-	}
-
-	pkgInfo := lprog.Package(importPath)
-
-	if pkgInfo == nil {
-		return nil, fmt.Errorf("package was nil")
-	}
-
-	// TODO: possibly cache pkgInfo since it's an expensive operation
-
-	for i := range pkgInfo.Files {
-		for _, astDeclaration := range pkgInfo.Files[i].Decls {
+	for i := range pkgInfo.Syntax {
+		for _, astDeclaration := range pkgInfo.Syntax[i].Decls {
 			generalDeclaration, ok := astDeclaration.(*ast.GenDecl)
 			if ok && generalDeclaration.Tok == token.TYPE {
 				for _, astSpec := range generalDeclaration.Specs {
@@ -711,7 +760,7 @@ func (operation *Operation) parseObjectSchema(refType string, astFile *ast.File)
 		}
 
 		return spec.MapProperty(schema), nil
-	case strings.Contains(refType, "{"):
+	case strings.HasSuffix(refType, "}"):
 		return operation.parseCombinedObjectSchema(refType, astFile)
 	default:
 		if operation.parser != nil { // checking refType has existing in 'TypeDefinitions'
@@ -742,11 +791,11 @@ func (operation *Operation) parseCombinedObjectSchema(refType string, astFile *a
 		n := 0
 
 		return strings.FieldsFunc(s, func(r rune) bool {
-			if r == '{' {
+			if r == '{' || r == '[' {
 				n++
 
 				return false
-			} else if r == '}' {
+			} else if r == '}' || r == ']' {
 				n--
 
 				return false
@@ -773,6 +822,16 @@ func (operation *Operation) parseCombinedObjectSchema(refType string, astFile *a
 		return schema, nil
 	}
 
+	// an inline object, e.g. object{id=integer,name=string}
+	if refType == OBJECT {
+		return &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type:       []string{OBJECT},
+				Properties: props,
+			},
+		}, nil
+	}
+
 	return spec.ComposedSchema(*schema, spec.Schema{
 		SchemaProps: spec.SchemaProps{
 			Type:       []string{OBJECT},
@@ -837,12 +896,39 @@ func (operation *Operation) ParseResponseComment(commentLine string, astFile *as
 		if resp.Description == "" {
 			resp.Description = http.StatusText(code)
 		}
+		if operation.parser != nil && operation.parser.IsOpenAPI3() {
+			resp = operation.mergeResponse(code, resp)
+		}
 		operation.AddResponse(code, resp)
 	}
 
 	return nil
 }
 
+// mergeResponse combines the schemas of responses declared more than once for the same code by oneOf.
+func (operation *Operation) mergeResponse(code int, response *spec.Response) *spec.Response {
+	if operation.Responses == nil || response.Schema == nil {
+		return response
+	}
+	exist, ok := operation.Responses.StatusCodeResponses[code]
+	if !ok || exist.Schema == nil {
+		return response
+	}
+
+	schema := exist.Schema
+	if len(schema.OneOf) == 0 || len(schema.Type) > 0 || schema.Ref.String() != "" {
+		schema = &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				OneOf: []spec.Schema{*exist.Schema},
+			},
+		}
+	}
+	schema.OneOf = append(schema.OneOf, *response.Schema)
+	exist.Schema = schema
+
+	return &exist
+}
+
 // ParseResponseHeaderComment parses comment for given `response header` comment string.
 func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *ast.File) error {
 	matches := responsePattern.FindStringSubmatch(commentLine)
@@ -858,7 +944,7 @@ func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *as
 	header.Type = schemaType
 
 	if strings.EqualFold(matches[1], "all") {
-		if operation.Responses.Default != nil {
+		if operation.Responses != nil && operation.Responses.Default != nil {
 			if operation.Responses.Default.Headers == nil {
 				operation.Responses.Default.Headers = make(map[string]spec.Header)
 			}
@@ -879,7 +965,7 @@ func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *as
 
 	for _, codeStr := range strings.Split(matches[1], ",") {
 		if strings.EqualFold(codeStr, "default") {
-			if operation.Responses.Default != nil {
+			if operation.Responses != nil && operation.Responses.Default != nil {
 				if operation.Responses.Default.Headers == nil {
 					operation.Responses.Default.Headers = make(map[string]spec.Header)
 				}
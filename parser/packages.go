@@ -2,13 +2,11 @@ package parser
 
 import (
 	"go/ast"
-	goparser "go/parser"
 	"go/token"
-	"os"
 	"path/filepath"
 	"strings"
 
-	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/packages"
 )
 
 // PackagesDefinitions map[package import path]*PackageDefinitions.
@@ -16,6 +14,19 @@ type PackagesDefinitions struct {
 	files             map[*ast.File]*AstFileInfo
 	packages          map[string]*PackageDefinitions
 	uniqueDefinitions map[string]*TypeSpecDef
+
+	// loader the cache of the loaded packages keyed by import path
+	loader *packageLoader
+	// externals the import paths of the external packages whose types are parsed
+	externals map[string]bool
+	// instances the instances of the generic types keyed by the qualified names, e.g. github.com/x/api.Response[github.com/x/models.User]
+	instances map[string]*TypeSpecDef
+	// renamedInstances the former names of the instances renamed by the collisions of their names
+	renamedInstances map[*TypeSpecDef]string
+	// enums the constants of the named types keyed by the qualified type names, e.g. github.com/x/models.Status
+	enums map[string][]EnumValue
+	// enumPackages the import paths of the packages whose constants are collected
+	enumPackages map[string]bool
 }
 
 // NewPackagesDefinitions create object PackagesDefinitions.
@@ -24,6 +35,11 @@ func NewPackagesDefinitions() *PackagesDefinitions {
 		files:             make(map[*ast.File]*AstFileInfo),
 		packages:          make(map[string]*PackageDefinitions),
 		uniqueDefinitions: make(map[string]*TypeSpecDef),
+		loader:            newPackageLoader(),
+		externals:         make(map[string]bool),
+		instances:         make(map[string]*TypeSpecDef),
+		enums:             make(map[string][]EnumValue),
+		enumPackages:      make(map[string]bool),
 	}
 }
 
@@ -93,7 +109,9 @@ func (pkgs *PackagesDefinitions) parseTypesFromFile(astFile *ast.File, packagePa
 						TypeSpec: typeSpec,
 					}
 
-					if idt, ok := typeSpec.Type.(*ast.Ident); ok && IsGolangPrimitiveType(idt.Name) && parsedSchemas != nil {
+					// the types with custom schemas or enums are parsed by ParseDefinition
+					if idt, ok := typeSpec.Type.(*ast.Ident); ok && IsGolangPrimitiveType(idt.Name) && parsedSchemas != nil &&
+						!pkgs.hasCustomSchema(typeSpecDef) && len(pkgs.enumValues(typeSpecDef)) == 0 {
 						parsedSchemas[typeSpecDef] = &Schema{
 							PkgPath: typeSpecDef.PkgPath,
 							Name:    astFile.Name.Name,
@@ -120,6 +138,7 @@ func (pkgs *PackagesDefinitions) parseTypesFromFile(astFile *ast.File, packagePa
 					if pkgs.packages[typeSpecDef.PkgPath] == nil {
 						pkgs.packages[typeSpecDef.PkgPath] = &PackageDefinitions{
 							Name:            astFile.Name.Name,
+							Files:           make(map[string]*ast.File),
 							TypeDefinitions: map[string]*TypeSpecDef{typeSpecDef.Name(): typeSpecDef},
 						}
 					} else if _, ok = pkgs.packages[typeSpecDef.PkgPath].TypeDefinitions[typeSpecDef.Name()]; !ok {
@@ -146,33 +165,43 @@ func (pkgs *PackagesDefinitions) findTypeSpec(pkgPath string, typeName string) *
 	return nil
 }
 
-func (pkgs *PackagesDefinitions) loadExternalPackage(importPath string) error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return err
+// cachePackages caches the loaded packages, so that they are not loaded again as external packages.
+func (pkgs *PackagesDefinitions) cachePackages(loaded ...*packages.Package) {
+	if pkgs.loader == nil {
+		pkgs.loader = newPackageLoader()
 	}
 
-	conf := loader.Config{
-		ParserMode: goparser.ParseComments,
-		Cwd:        cwd,
-	}
+	pkgs.loader.add(loaded...)
+}
 
-	conf.Import(importPath)
+func (pkgs *PackagesDefinitions) loadExternalPackage(importPath string) error {
+	if pkgs.loader == nil {
+		pkgs.loader = newPackageLoader()
+	}
+	if pkgs.externals == nil {
+		pkgs.externals = make(map[string]bool)
+	}
 
-	lprog, err := conf.Load()
+	pkg, err := pkgs.loader.load(importPath)
 	if err != nil {
 		return err
 	}
 
-	for _, info := range lprog.AllPackages {
-		pkgPath := info.Pkg.Path()
+	packages.Visit([]*packages.Package{pkg}, func(info *packages.Package) bool {
+		if pkgs.externals[info.PkgPath] {
+			return false
+		}
+		pkgs.externals[info.PkgPath] = true
+
+		pkgPath := info.PkgPath
 		if strings.HasPrefix(pkgPath, "vendor/") {
 			pkgPath = pkgPath[7:]
 		}
-		for _, astFile := range info.Files {
+		for _, astFile := range info.Syntax {
 			pkgs.parseTypesFromFile(astFile, pkgPath, nil)
 		}
-	}
+		return true
+	}, nil)
 
 	return nil
 }
@@ -259,6 +288,32 @@ func (pkgs *PackagesDefinitions) FindTypeSpec(typeName string, file *ast.File, p
 		return pkgs.uniqueDefinitions[typeName]
 	}
 
+	// an instantiated generic type, e.g. Response[models.User]
+	if name, args, ok := splitGenericTypeName(typeName); ok {
+		if pkgs.instances == nil {
+			pkgs.instances = make(map[string]*TypeSpecDef)
+		}
+		return pkgs.instantiate(name, args, file, parseDependency)
+	}
+
+	// a type qualified by the import path of its package, e.g. github.com/x/models.User
+	if slash := strings.LastIndex(typeName, "/"); slash >= 0 {
+		dot := strings.Index(typeName[slash:], ".")
+		if dot < 0 {
+			return nil
+		}
+		pkgPath, name := typeName[:slash+dot], typeName[slash+dot+1:]
+		typeDef := pkgs.findTypeSpec(pkgPath, name)
+		if typeDef == nil && parseDependency {
+			if err := pkgs.loadExternalPackage(pkgPath); err != nil {
+				return nil
+			}
+			typeDef = pkgs.findTypeSpec(pkgPath, name)
+		}
+
+		return typeDef
+	}
+
 	parts := strings.Split(typeName, ".")
 	if len(parts) > 1 {
 		isAliasPkgName := func(file *ast.File, pkgName string) bool {
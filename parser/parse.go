@@ -12,26 +12,52 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
-	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/spec"
+	"golang.org/x/tools/go/packages"
 )
 
 type SwaggerConfig struct {
 	ParseDirs      []string
 	Filter         string
 	PrintGenerate  bool
-	SwaggerOptions []func(*Parser)
+	SwaggerOptions []func(*Parser) `json:"-"`
 	// SwaggerURL        string
 	OutputDir         string
 	FormatSwaggerJSON bool
+	// OpenAPIVersion 2.0 (default), 3.0 or 3.1
+	OpenAPIVersion string
+	// BuildTags the build tags used to load the packages
+	BuildTags []string
+	// Incremental caches the generated annotations in OutputDir, only the dirs with changed files are generated again
+	Incremental bool
+	// WriteBack writes the generated comments back to the doc comments of the handlers, the other comments are kept
+	WriteBack bool
+	// DryRun prints the diff of WriteBack instead of writing the files
+	DryRun bool
+	// CallDepth the depth of the calls followed from a handler, DefaultCallDepth by default, negative to disable
+	CallDepth int
+	// SecurityMiddlewares the auth middlewares keyed by symbol and the security definitions they require,
+	// e.g. github.com/x/auth.JWT: JWT, merged with DefaultSecurityMiddlewares
+	SecurityMiddlewares map[string]string
+	// RequiredByDefault the fields of the structs without omitempty are required
+	RequiredByDefault bool
 }
 
+const (
+	// GinFilter the signature of gin handlers, the default Filter
+	GinFilter = "func(*gin.Context)"
+	// HTTPFilter the signature of net/http handlers, which are always matched
+	HTTPFilter = "func(http.ResponseWriter, *http.Request)"
+)
+
 type Option func(*SwaggerConfig)
 
-func ParseDir(app *gin.Engine, options ...Option) error {
+func ParseDir(source RouteSource, options ...Option) error {
 	var (
-		fileSet token.FileSet
+		fileSet = token.NewFileSet()
 		config  SwaggerConfig
 	)
 
@@ -54,63 +80,148 @@ func ParseDir(app *gin.Engine, options ...Option) error {
 			p.ParseDependency = true
 		})
 	}
-	config.Filter = "func(*gin.Context)"
+	if config.OpenAPIVersion != "" {
+		if _, err := normalizeOpenAPIVersion(config.OpenAPIVersion); err != nil {
+			return err
+		}
+		config.SwaggerOptions = append(config.SwaggerOptions, SetOpenAPIVersion(config.OpenAPIVersion))
+	}
+	if config.RequiredByDefault {
+		config.SwaggerOptions = append(config.SwaggerOptions, func(p *Parser) {
+			p.RequiredByDefault = true
+		})
+	}
+	if config.Filter == "" {
+		config.Filter = GinFilter
+	}
+	if config.CallDepth == 0 {
+		config.CallDepth = DefaultCallDepth
+	}
+	filters := []string{config.Filter}
+	if config.Filter != HTTPFilter {
+		filters = append(filters, HTTPFilter)
+	}
 
 	if len(config.ParseDirs) > 0 {
 		p := New(config.SwaggerOptions...)
+		routeInfos := GetRouteInfos(source)
 
-		for _, path := range config.ParseDirs {
-			packageDir, err := getPkgName(path)
-			if err != nil {
-				log.Printf("warning: failed to get package name in dir: %s, error: %s", path, err.Error())
-			}
+		securities := make(map[string]string, len(DefaultSecurityMiddlewares)+len(config.SecurityMiddlewares))
+		for middleware, name := range DefaultSecurityMiddlewares {
+			securities[middleware] = name
+		}
+		for middleware, name := range config.SecurityMiddlewares {
+			securities[middleware] = name
+		}
+		if basePath := groupRoutes(routeInfos, securities); basePath != "" {
+			p.swagger.BasePath = basePath
+		}
+
+		cache := newGenerateCache(generateCacheKey(routeInfos, filters, config.CallDepth, config.BuildTags))
+		previous := newGenerateCache("")
+		if config.Incremental {
+			previous = loadGenerateCache(config.OutputDir)
+		}
+		dirty, err := cache.scan(previous, config.ParseDirs)
+		if err != nil {
+			return err
+		}
 
-			err = p.getAllGoFileInfo(packageDir, path)
+		// load all the changed packages first, so that the calls into the other packages can be followed
+		dirtyDirs := make([]string, 0, len(config.ParseDirs))
+		for i, path := range config.ParseDirs {
+			if dirty[i] {
+				dirtyDirs = append(dirtyDirs, path)
+			}
+		}
+		loaded := make(map[string]*packages.Package, len(dirtyDirs))
+		index := make(funcIndex)
+		if len(dirtyDirs) > 0 {
+			pkgs, err := loadPackages(fileSet, dirtyDirs, config.BuildTags)
 			if err != nil {
 				return err
 			}
+			p.packages.cachePackages(pkgs...)
+			index.addPackages(fileSet, pkgs)
+			for i, pkg := range pkgs {
+				loaded[dirtyDirs[i]] = pkg
+			}
+		}
+
+		files := make([]GinSwaggerFile, 0)
+		for _, path := range config.ParseDirs {
+			pkg, exist := loaded[path]
+			if !exist {
+				// the package is not changed, use the cached comments
+				cached, trees, err := cache.restore(fileSet, path)
+				if err != nil {
+					return err
+				}
+				if err := p.getAllGoFileInfo(cached.PkgPath, path); err != nil {
+					return err
+				}
+				for _, tree := range trees {
+					fileName := fileSet.Position(tree.Package).Filename
+					if config.WriteBack {
+						if err := writeBackFile(cache, fileName, cache.Files[fileName].Comments, config.DryRun); err != nil {
+							return err
+						}
+					}
+					files = append(files, GinSwaggerFile{Dir: path, Name: filepath.Base(fileName), Tree: tree})
+				}
+				continue
+			}
 
-			packages, err := parser.ParseDir(&fileSet, path, nil, parser.ParseComments)
+			err := p.getAllGoFileInfo(pkg.PkgPath, path)
 			if err != nil {
 				return err
 			}
 
-			// iterate over all packages in the directory
-			for _, pkg := range packages {
-				// iterate over all files within the package
-				for name, astTree := range pkg.Files {
-					baseName := filepath.Base(name)
+			compiled := make([]string, 0, len(pkg.Syntax))
+			// iterate over all files within the package
+			for _, astTree := range pkg.Syntax {
+				fileName := fileSet.Position(astTree.Package).Filename
+				baseName := filepath.Base(fileName)
 
-					fileAST, err := ParseFileAST(baseName, astTree, fileSet, GetGinRouteInfos(app), config.Filter, config.PrintGenerate)
-					if err != nil {
-						return err
-					}
+				fileAST, err := parseFileAST(baseName, astTree, fileSet, pkg.TypesInfo, index, routeInfos, filters, config.CallDepth, config.PrintGenerate)
+				if err != nil {
+					return err
+				}
 
-					if fileAST != nil {
-						if err := p.GinSwagger(path, baseName, fileAST.source); err != nil {
+				if fileAST != nil {
+					cache.record(fileName, fileAST.Functions)
+					if config.WriteBack {
+						if err := writeBackFile(cache, fileName, generatedComments(fileAST.Functions), config.DryRun); err != nil {
 							return err
 						}
 					}
+					files = append(files, GinSwaggerFile{Dir: path, Name: baseName, Tree: fileAST.source})
+				}
+				compiled = append(compiled, fileName)
+			}
+			cache.recordDir(path, pkg.PkgPath, compiled)
+		}
 
-					// if err := p.GinSwagger(path, baseName, astTree); err != nil {
-					// 	return err
-					// }
+		if err := p.GinSwaggerFiles(files); err != nil {
+			return err
+		}
+		addSecurityDefinitions(p, routeInfos)
 
-				}
+		if config.Incremental {
+			if err := cache.save(config.OutputDir); err != nil {
+				log.Printf("[WARNING] failed to save the cache in %s, error: %s", config.OutputDir, err)
 			}
 		}
 
-		var (
-			bytes []byte
-			err   error
-		)
+		var bytes []byte
+		document := p.GetDocument()
 		if config.FormatSwaggerJSON {
-			bytes, err = json.MarshalIndent(p.GetSwagger(), "", "  ")
+			bytes, err = json.MarshalIndent(document, "", "  ")
 			if err != nil {
 				return err
 			}
 		} else {
-			bytes, err = p.GetSwagger().MarshalJSON()
+			bytes, err = json.Marshal(document)
 			if err != nil {
 				return err
 			}
@@ -124,10 +235,71 @@ func ParseDir(app *gin.Engine, options ...Option) error {
 	return nil
 }
 
-func ParseFileAST(name string, tree *ast.File, fileSet token.FileSet, routeInfos map[string]gin.RouteInfo, filterStr string, printGenerate bool) (*File, error) {
+// addSecurityDefinitions adds the security definitions required by the routes which are not defined and are
+// referred by the operations, BasicAuth is defined as basic auth and the others as an api key in the Authorization header.
+func addSecurityDefinitions(p *Parser, routeInfos map[string][]RouteInfo) {
+	referred := operationSecurities(p.swagger)
+	for _, info := range flattenRoutes(routeInfos) {
+		for _, name := range info.Security {
+			if _, exist := p.swagger.SecurityDefinitions[name]; exist || !referred[name] {
+				continue
+			}
+			if p.swagger.SecurityDefinitions == nil {
+				p.swagger.SecurityDefinitions = make(map[string]*spec.SecurityScheme)
+			}
+			if name == "BasicAuth" {
+				p.swagger.SecurityDefinitions[name] = spec.BasicAuth()
+			} else {
+				p.swagger.SecurityDefinitions[name] = spec.APIKeyAuth("Authorization", "header")
+			}
+			log.Printf("[INFO] add the security definition %s", name)
+		}
+	}
+}
+
+// operationSecurities returns the names of the securities referred by the document and its operations.
+func operationSecurities(swagger *spec.Swagger) map[string]bool {
+	requirements := append([]map[string][]string{}, swagger.Security...)
+	if swagger.Paths != nil {
+		for _, pathItem := range swagger.Paths.Paths {
+			for _, operation := range []*spec.Operation{pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete, pathItem.Options, pathItem.Head, pathItem.Patch} {
+				if operation != nil {
+					requirements = append(requirements, operation.Security...)
+				}
+			}
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, requirement := range requirements {
+		for name := range requirement {
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+// writeBackFile writes the generated comments back to the file, or prints the diff if dryRun.
+func writeBackFile(cache *generateCache, fileName string, comments map[string][]string, dryRun bool) error {
+	changed, err := writeBack(fileName, comments, dryRun, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("write back file %s, %w", fileName, err)
+	}
+	if changed && !dryRun {
+		log.Printf("[INFO] write back %s", fileName)
+		// the file is changed by the generator, there is no need to generate it again
+		cache.refresh(fileName)
+	}
+
+	return nil
+}
+
+// ParseFileAST generates the swagger comments of the handlers in a file, the file is type checked alone.
+func ParseFileAST(name string, tree *ast.File, fileSet *token.FileSet, routeInfos map[string][]RouteInfo, filters []string, callDepth int, printGenerate bool) (*File, error) {
 
 	config := types.Config{
-		Importer: importer.ForCompiler(&fileSet, "source", nil),
+		Importer: importer.ForCompiler(fileSet, "source", nil),
 	}
 
 	info := types.Info{
@@ -141,143 +313,214 @@ func ParseFileAST(name string, tree *ast.File, fileSet token.FileSet, routeInfos
 		Selections: make(map[*ast.SelectorExpr]*types.Selection),
 	}
 
-	if _, err := config.Check("", &fileSet, []*ast.File{tree}, &info); err != nil {
+	if _, err := config.Check("", fileSet, []*ast.File{tree}, &info); err != nil {
 		return nil, err
 	}
 
+	index := make(funcIndex)
+	index.add(fileSet, tree, &info)
+
+	return parseFileAST(name, tree, fileSet, &info, index, routeInfos, filters, callDepth, printGenerate)
+}
+
+// parseFileAST generates the swagger comments of the handlers in a file with the type info of its package.
+func parseFileAST(name string, tree *ast.File, fileSet *token.FileSet, info *types.Info, index funcIndex, routeInfos map[string][]RouteInfo, filters []string, callDepth int, printGenerate bool) (*File, error) {
+	// the normalized signature to its filter
+	excepts := make(map[string]string, len(filters))
+	for _, filterStr := range filters {
+		expr, err := parser.ParseExpr(filterStr)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		excepts[strings.ReplaceAll(ExprString(expr), " ", "")] = filterStr
+	}
+
 	functionDescs := []FunctionDesc{}
 
 	fileComments := []*ast.CommentGroup{}
 
-	for _, declaration := range tree.Decls {
+	// generate generates the comments of a handler declared by decl, body is the body of the handler,
+	// which is the returned closure of decl if decl is a handler factory
+	qualifier := fileQualifier(tree, info)
+
+	generate := func(decl *ast.FuncDecl, funcType *ast.FuncType, body *ast.BlockStmt, filter, name, closure string) {
+		functionDesc := FunctionDesc{
+			source:    decl,
+			fset:      fileSet,
+			closure:   closure,
+			qualifier: qualifier,
+
+			Name:        name,
+			Comments:    make([]string, 0),
+			PackageName: funcPackageName(tree, decl),
+			Filter:      filter,
+			Params:      parseFuncItemInfo(funcType.Params, info),
+			Results:     parseFuncItemInfo(funcType.Results, info),
+			Vars:        make(map[string]FuncItem),
+			Exprs:       make([]ExprItem, 0),
+		}
 
-		switch decValue := declaration.(type) {
-		case *ast.FuncDecl:
-			expr, err := parser.ParseExpr(filterStr)
-			if err != nil {
-				log.Println(err)
-				continue
+		if decl.Doc != nil && decl.Doc.List != nil {
+			for _, comment := range decl.Doc.List {
+				functionDesc.Comments = append(functionDesc.Comments, comment.Text)
 			}
+		}
 
-			except := strings.ReplaceAll(ExprString(expr), " ", "")
-			act := strings.ReplaceAll(ExprString(decValue.Type), " ", "")
-			if except != act {
-				continue
-			}
+		collector := newExprCollector(index, callDepth, functionDesc.Params, qualifier)
+		if fn, ok := info.Defs[decl.Name].(*types.Func); ok {
+			collector.visiting[fn.FullName()] = true
+		}
+		collector.collect(&functionDesc, body, info, 0, nil)
+		functionDesc.deps = collector.dependencies()
 
-			log.Printf("[INFO] match %s named %s at line %s", except, decValue.Name.Name, fileSet.Position(decValue.Pos()))
-			functionDesc := FunctionDesc{
-				source: decValue,
-				fset:   fileSet,
-
-				Name:        decValue.Name.Name,
-				Comments:    make([]string, 0),
-				PackageName: fmt.Sprintf("%s.%s", tree.Name.Name, decValue.Name.Name),
-				Params:      parseFuncItemInfo(decValue.Type.Params, info),
-				Results:     parseFuncItemInfo(decValue.Type.Results, info),
-				Vars:        make(map[string]FuncItem),
-				Exprs:       make([]ExprItem, 0),
-			}
+		functionDescs = append(functionDescs, functionDesc)
 
-			if decValue.Doc != nil && decValue.Doc.List != nil {
-				for _, comment := range decValue.Doc.List {
-					functionDesc.Comments = append(functionDesc.Comments, comment.Text)
-				}
-			}
+		var comments []string
+		if functionDesc.Filter == HTTPFilter {
+			comments = GetHTTPComments(functionDesc, routeInfos)
+		} else {
+			comments = GetGinComments(functionDesc, routeInfos)
+		}
+		commentMap := newCommentGroup(comments)
 
-			if decValue.Recv != nil && decValue.Recv.List != nil {
-				recv := decValue.Recv.List[0]
-				functionDesc.PackageName = fmt.Sprintf("%s.%s.%s", tree.Name.Name, strings.TrimPrefix(ExprString(recv.Type), "*"), decValue.Name.Name)
-			}
+		decl.Doc = commentMap
 
-			ast.Inspect(decValue.Body, func(n ast.Node) bool {
-				switch node := n.(type) {
-				// 获取函数体变量
-				case *ast.Ident:
-					if info.Defs[node] != nil {
-						functionDesc.Vars[node.Name] = FuncItem{
-							Name: node.Name,
-							Type: info.Defs[node].Type().String(),
-						}
-					}
-				// 获取函数内函数调用
-				case *ast.CallExpr:
-					selector, ok := node.Fun.(*ast.SelectorExpr)
-					if !ok {
-						return true
-					}
+		fileComments = append(fileComments, commentMap)
 
-					selectorType, exist := info.Selections[selector]
-					if !exist {
-						return true
-					}
+		if printGenerate {
+			printer.Fprint(os.Stdout, fileSet, decl)
+			fmt.Println()
+		}
+	}
 
-					if selectorType.Kind() != types.MethodVal {
-						return true
-					}
+	// the declarations of the closures registered as handlers, e.g. app.GET("/ping", func(ctx *gin.Context) {...})
+	closureDecls := make([]ast.Decl, 0)
 
-					args := make([]ExprArgItem, 0)
+	for _, declaration := range tree.Decls {
+		decValue, ok := declaration.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
 
-					for _, argEntry := range node.Args {
-						argType, exist := info.Types[argEntry]
-						if !exist {
-							continue
-						}
+		act := strings.ReplaceAll(ExprString(decValue.Type), " ", "")
+		if filter, exist := excepts[act]; exist {
+			log.Printf("[INFO] match %s named %s at line %s", act, decValue.Name.Name, fileSet.Position(decValue.Pos()))
+			generate(decValue, decValue.Type, decValue.Body, filter, decValue.Name.Name, "")
+			continue
+		}
+		if decValue.Body == nil {
+			continue
+		}
 
-						var value string
-						if argType.Value != nil {
-							value = argType.Value.ExactString()
-						}
+		// the closures of the handler factories and the closures registered inline
+		returned := returnedFuncLit(decValue.Body)
+		for _, closure := range funcLits(decValue.Body) {
+			act := strings.ReplaceAll(ExprString(closure.lit.Type), " ", "")
+			filter, exist := excepts[act]
+			// the closures of the same signature not registered, e.g. the middlewares returned by func Auth() gin.HandlerFunc
+			if !exist || len(handlerRoutes(routeInfos, funcPackageName(tree, decValue)+"."+closure.name)) == 0 {
+				continue
+			}
 
-						args = append(args, ExprArgItem{
-							Type:  argType.Type.String(),
-							Name:  ExprString(argEntry),
-							Value: value,
-						})
-					}
+			log.Printf("[INFO] match %s named %s.%s at line %s", act, decValue.Name.Name, closure.name, fileSet.Position(closure.lit.Pos()))
+			if closure.lit == returned {
+				generate(decValue, closure.lit.Type, closure.lit.Body, filter, decValue.Name.Name, closure.name)
+				continue
+			}
 
-					functionDesc.Exprs = append(functionDesc.Exprs, ExprItem{
-						Receiver: selectorType.Recv().String(),
-						Name:     selectorType.Obj().Name(),
-						Args:     args,
-					})
+			// the closure can not be annotated in the source, its comments are attached to a declaration added to the tree
+			closureDecl := &ast.FuncDecl{
+				Name: ast.NewIdent(closureDeclName(decValue, closure.name)),
+				Type: closure.lit.Type,
+			}
+			generate(closureDecl, closure.lit.Type, closure.lit.Body, filter, decValue.Name.Name+"."+closure.name, "")
+			closureDecls = append(closureDecls, closureDecl)
+		}
+	}
+	tree.Decls = append(tree.Decls, closureDecls...)
+	tree.Comments = append(tree.Comments, fileComments...)
+	file := NewFile(name, tree)
+	file.Functions = functionDescs
 
-				}
+	return file, nil
+}
 
-				return true
-			})
+func newCommentGroup(comments []string) *ast.CommentGroup {
+	commentMap := &ast.CommentGroup{List: make([]*ast.Comment, len(comments))}
+	for index, comment := range comments {
+		commentMap.List[index] = &ast.Comment{
+			Text: comment,
+		}
+	}
 
-			functionDescs = append(functionDescs, functionDesc)
+	return commentMap
+}
 
-			comments := GetGinComments(functionDesc, routeInfos)
-			commentMap := &ast.CommentGroup{List: make([]*ast.Comment, len(comments))}
-			for index, comment := range comments {
-				commentMap.List[index] = &ast.Comment{
-					Text: comment,
-				}
-			}
+// closureDeclName returns the name of the declaration added for a closure of decl, e.g. Controller.Register.func1.
+func closureDeclName(decl *ast.FuncDecl, closure string) string {
+	name := decl.Name.Name + "." + closure
+	if decl.Recv != nil && decl.Recv.List != nil {
+		name = strings.TrimPrefix(ExprString(decl.Recv.List[0].Type), "*") + "." + name
+	}
 
-			decValue.Doc = commentMap
+	return name
+}
 
-			fileComments = append(fileComments, commentMap)
+// fileQualifier names the packages as the file refers to them, so that the names are resolved from the file by
+// PackagesDefinitions.FindTypeSpec: the name of the file's package, the name of an import, or else the import path.
+func fileQualifier(tree *ast.File, info *types.Info) types.Qualifier {
+	names := make(map[string]string, len(tree.Imports))
+	for _, spec := range tree.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		var name string
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if pkgName, ok := info.Implicits[spec].(*types.PkgName); ok {
+			name = pkgName.Name()
+		} else {
+			name = path[strings.LastIndex(path, "/")+1:]
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		names[path] = name
+	}
 
-			if printGenerate {
-				printer.Fprint(os.Stdout, &fileSet, decValue)
-				fmt.Println()
-			}
-		default:
-			// fmt.Printf("(AST: %T) Skiping\n", decValue)
+	var own *types.Package
+	for _, obj := range info.Defs {
+		if obj != nil && obj.Pkg() != nil {
+			own = obj.Pkg()
+			break
 		}
+	}
 
+	return func(pkg *types.Package) string {
+		if pkg == own {
+			return pkg.Name()
+		}
+		if name, exist := names[pkg.Path()]; exist {
+			return name
+		}
+
+		return pkg.Path()
 	}
-	tree.Comments = append(tree.Comments, fileComments...)
-	file := NewFile(name, tree)
-	file.Functions = functionDescs
+}
 
-	return file, nil
+// funcPackageName returns the name of a function qualified by its package and receiver, e.g. api.Controller.Get.
+func funcPackageName(tree *ast.File, decl *ast.FuncDecl) string {
+	if decl.Recv != nil && decl.Recv.List != nil {
+		recv := decl.Recv.List[0]
+		return fmt.Sprintf("%s.%s.%s", tree.Name.Name, strings.TrimPrefix(ExprString(recv.Type), "*"), decl.Name.Name)
+	}
+
+	return fmt.Sprintf("%s.%s", tree.Name.Name, decl.Name.Name)
 }
 
-func parseFuncItemInfo(node *ast.FieldList, info types.Info) []FuncItem {
+func parseFuncItemInfo(node *ast.FieldList, info *types.Info) []FuncItem {
 	items := []FuncItem{}
 
 	if node == nil || node.List == nil {
@@ -300,11 +543,3 @@ func parseFuncItemInfo(node *ast.FieldList, info types.Info) []FuncItem {
 
 	return items
 }
-
-func GetGinRouteInfos(app *gin.Engine) map[string]gin.RouteInfo {
-	routes := make(map[string]gin.RouteInfo)
-	for _, info := range app.Routes() {
-		routes[info.Handler] = info
-	}
-	return routes
-}
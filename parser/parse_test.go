@@ -0,0 +1,32 @@
+package parser
+
+import (
+	goparser "go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestParseFileAST(t *testing.T) {
+	fileSet := token.NewFileSet()
+	tree, err := goparser.ParseFile(fileSet, "testdata/nethttp/handlers.go", nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := ParseFileAST("handlers.go", tree, fileSet, nil, []string{HTTPFilter}, DefaultCallDepth, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`// @Failure 404 "Not Found"`,
+		`// @Param id path string true "id"`,
+		"// @Produce json",
+		"// @Success 200 {object} nethttp.User",
+		"// @Summary GetUser",
+	}
+	if got := generatedComments(file.Functions)["nethttp.GetUser"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("comments of nethttp.GetUser = %q, expected %q", got, expected)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
-	"go/build"
 	goparser "go/parser"
 	"go/token"
 	"io/ioutil"
@@ -13,12 +12,13 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/KyleBanks/depth"
@@ -56,6 +56,9 @@ type Parser struct {
 	// swagger represents the root document object for the API specification
 	swagger *spec.Swagger
 
+	// openAPIVersion the version of the output document, the swagger document is converted when it is 3.x
+	openAPIVersion string
+
 	// packages store entities of APIs, definitions, file, package path etc.  and their relations
 	packages *PackagesDefinitions
 
@@ -89,6 +92,9 @@ type Parser struct {
 	// Strict whether swag should error or warn when it detects cases which are most likely user errors
 	Strict bool
 
+	// RequiredByDefault whether the fields without omitempty are required, which are always encoded
+	RequiredByDefault bool
+
 	// structStack stores full names of the structures that were already parsed or are being parsed now
 	structStack []*TypeSpecDef
 
@@ -104,6 +110,9 @@ type Parser struct {
 	// excludes excludes dirs and files in SearchDir
 	excludes map[string]bool
 
+	// typeMappings the schemas of the types set by SetTypeMappings, keyed by the qualified names
+	typeMappings map[string]spec.Schema
+
 	// debugging output goes here
 	debug Debugger
 }
@@ -136,6 +145,7 @@ func New(options ...func(*Parser)) *Parser {
 				SecurityDefinitions: make(map[string]*spec.SecurityScheme),
 			},
 		},
+		openAPIVersion:     OpenAPIVersion2,
 		packages:           NewPackagesDefinitions(),
 		debug:              log.New(os.Stdout, "", log.LstdFlags),
 		parsedSchemas:      make(map[*TypeSpecDef]*Schema),
@@ -193,28 +203,6 @@ func SetDebugger(logger Debugger) func(parser *Parser) {
 	}
 }
 
-func getPkgName(searchDir string) (string, error) {
-	cmd := exec.Command("go", "list", "-f={{.ImportPath}}")
-	cmd.Dir = searchDir
-	var stdout, stderr strings.Builder
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("execute go list command, %s, stdout:%s, stderr:%s", err, stdout.String(), stderr.String())
-	}
-
-	outStr, _ := stdout.String(), stderr.String()
-
-	if outStr[0] == '_' { // will shown like _/{GOPATH}/src/{YOUR_PACKAGE} when NOT enable GO MODULE.
-		outStr = strings.TrimPrefix(outStr, "_"+build.Default.GOPATH+"/src/")
-	}
-	f := strings.Split(outStr, "\n")
-	outStr = f[0]
-
-	return outStr, nil
-}
-
 func initIfEmpty(license *spec.License) *spec.License {
 	if license == nil {
 		return new(spec.License)
@@ -230,7 +218,7 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 		return fmt.Errorf("cannot parse source files %s: %s", mainAPIFile, err)
 	}
 
-	parser.swagger.Swagger = "2.0"
+	parser.swagger.Swagger = OpenAPIVersion2
 
 	for _, comment := range fileTree.Comments {
 		comments := strings.Split(comment.Text(), "\n")
@@ -247,55 +235,39 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 }
 
 func (parser *Parser) GinSwagger(dir string, fileName string, fileTree *ast.File) error {
+	return parser.GinSwaggerFiles([]GinSwaggerFile{{Dir: dir, Name: fileName, Tree: fileTree}})
+}
+
+// GinSwaggerFile a file with the generated comments.
+type GinSwaggerFile struct {
+	Dir  string
+	Name string
+	Tree *ast.File
+}
+
+// GinSwaggerFiles parses the operations of files, the types are parsed once for all the files.
+func (parser *Parser) GinSwaggerFiles(files []GinSwaggerFile) error {
 	var err error
-	err = parser.packages.CollectAstFile(dir, fileName, fileTree)
-	if err != nil {
-		return err
+	for _, file := range files {
+		err = parser.packages.CollectAstFile(file.Dir, file.Name, file.Tree)
+		if err != nil {
+			return err
+		}
 	}
-	parser.swagger.Swagger = "2.0"
+	parser.swagger.Swagger = OpenAPIVersion2
 
 	parser.parsedSchemas, err = parser.packages.ParseTypes()
 	if err != nil {
 		return err
 	}
 
-	for _, astDescription := range fileTree.Decls {
-		astDeclaration, ok := astDescription.(*ast.FuncDecl)
-		if ok && astDeclaration.Doc != nil && astDeclaration.Doc.List != nil {
-			// for per 'function' comment, create a new 'Operation' object
-			operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
-			for _, comment := range astDeclaration.Doc.List {
-				err := operation.ParseComment(comment.Text, fileTree)
-				if err != nil {
-					return fmt.Errorf("ParseComment error in file %s :%+v", fileName, err)
-				}
-			}
-
-			for _, routeProperties := range operation.RouterProperties {
-				var pathItem spec.PathItem
-				var ok bool
-
-				pathItem, ok = parser.swagger.Paths.Paths[routeProperties.Path]
-				if !ok {
-					pathItem = spec.PathItem{}
-				}
-
-				// check if we already have a operation for this path and method
-				if hasRouteMethodOp(pathItem, routeProperties.HTTPMethod) {
-					err := fmt.Errorf("route %s %s is declared multiple times", routeProperties.HTTPMethod, routeProperties.Path)
-					if parser.Strict {
-						return err
-					}
-					parser.debug.Printf("warning: %s\n", err)
-				}
-
-				setRouteMethodOp(&pathItem, routeProperties.HTTPMethod, &operation.Operation)
-
-				parser.swagger.Paths.Paths[routeProperties.Path] = pathItem
-			}
+	for _, file := range files {
+		if err := parser.ParseRouterAPIInfo(file.Name, file.Tree); err != nil {
+			return err
 		}
 	}
 
+	parser.renameInstanceSchemas()
 	parser.renameRefSchemas()
 
 	return parser.checkOperationIDUniqueness()
@@ -622,7 +594,7 @@ func isExistsScope(scope string) (bool, error) {
 	for _, v := range s {
 		if strings.Contains(v, scopeAttrPrefix) {
 			if strings.Contains(v, ",") {
-				return false, fmt.Errorf("@scope can't use comma(,) get=" + v)
+				return false, fmt.Errorf("@scope can't use comma(,) get=%s", v)
 			}
 		}
 	}
@@ -739,6 +711,10 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 		return PrimitiveSchema(TransToValidSchemeType(typeName)), nil
 	}
 
+	if schema := parser.wellKnownSchema(typeName, file); schema != nil {
+		return schema, nil
+	}
+
 	schemaType, err := convertFromSpecificToPrimitive(typeName)
 	if err == nil {
 		return PrimitiveSchema(schemaType), nil
@@ -766,7 +742,8 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 		return parser.getRefTypeSchema(typeSpecDef, schema), nil
 	}
 
-	return schema.Schema, nil
+	// the inlined schemas are changed by the fields, e.g. the descriptions
+	return copySchema(schema.Schema), nil
 }
 
 func (parser *Parser) renameRefSchemas() {
@@ -794,6 +771,37 @@ func (parser *Parser) renameRefSchemas() {
 	}
 }
 
+// renameInstanceSchemas renames the schemas of the instances of the generic types which are renamed after being
+// parsed, since the names of the instances found later collide with them, and the references to them.
+func (parser *Parser) renameInstanceSchemas() {
+	names := make(map[string]string)
+	for typeSpecDef := range parser.packages.renamedInstances {
+		name := TypeDocName(typeSpecDef.FullName(), typeSpecDef.TypeSpec)
+		for _, schema := range []*Schema{parser.parsedSchemas[typeSpecDef], parser.outputSchemas[typeSpecDef]} {
+			if schema == nil || schema.Name == name {
+				continue
+			}
+			if definition, ok := parser.swagger.Definitions[schema.Name]; ok {
+				delete(parser.swagger.Definitions, schema.Name)
+				parser.swagger.Definitions[name] = definition
+			}
+			names[schema.Name] = name
+			schema.Name = name
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	for _, refURL := range parser.toBeRenamedRefURLs {
+		parts := strings.Split(refURL.Fragment, "/")
+		if name, ok := names[parts[len(parts)-1]]; ok {
+			parts[len(parts)-1] = name
+			refURL.Fragment = strings.Join(parts, "/")
+		}
+	}
+}
+
 func (parser *Parser) renameSchema(name, pkgPath string) string {
 	parts := strings.Split(name, ".")
 	name = fullTypeName(pkgPath, parts[len(parts)-1])
@@ -871,10 +879,19 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 
 	parser.debug.Printf("Generating %s", typeName)
 
-	definition, err := parser.parseTypeExpr(typeSpecDef.File, typeSpecDef.TypeSpec.Type, false)
+	definition, err := parser.packages.customSchema(typeSpecDef, parser.ParseDependency)
 	if err != nil {
 		return nil, err
 	}
+	if definition == nil {
+		definition, err = parser.parseTypeExpr(typeSpecDef.File, typeSpecDef.TypeSpec.Type, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if enums := parser.packages.enumValues(typeSpecDef); len(enums) > 0 {
+		setEnums(definition, enums)
+	}
 
 	s := Schema{
 		Name:    refTypeName,
@@ -925,8 +942,20 @@ func (parser *Parser) parseTypeExpr(file *ast.File, typeExpr ast.Expr, ref bool)
 		if xIdent, ok := expr.X.(*ast.Ident); ok {
 			return parser.getTypeSchema(fullTypeName(xIdent.Name, expr.Sel.Name), file, ref)
 		}
+	// type Foo Bar[Baz]
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		typeName, err := getFieldType(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return parser.getTypeSchema(typeName, file, ref)
 	// type Foo []Baz
 	case *ast.ArrayType:
+		// encoding/json encodes []byte as a base64 string
+		if ident, ok := expr.Elt.(*ast.Ident); ok && expr.Len == nil && (ident.Name == "byte" || ident.Name == "uint8") {
+			return formatSchema(STRING, "byte"), nil
+		}
 		itemSchema, err := parser.parseTypeExpr(file, expr.Elt, true)
 		if err != nil {
 			return nil, err
@@ -956,23 +985,17 @@ func (parser *Parser) parseTypeExpr(file *ast.File, typeExpr ast.Expr, ref bool)
 }
 
 func (parser *Parser) parseStruct(file *ast.File, fields *ast.FieldList) (*spec.Schema, error) {
+	props, err := parser.structProperties(file, fields, 0, false, make(map[*TypeSpecDef]bool))
+	if err != nil {
+		return nil, err
+	}
+
 	required := make([]string, 0)
 	properties := make(map[string]spec.Schema)
-	for _, field := range fields.List {
-		fieldProps, requiredFromAnon, err := parser.parseStructField(file, field)
-		if err != nil {
-			if err == ErrFuncTypeField {
-				continue
-			}
-
-			return nil, err
-		}
-		if len(fieldProps) == 0 {
-			continue
-		}
-		required = append(required, requiredFromAnon...)
-		for k, v := range fieldProps {
-			properties[k] = v
+	for _, prop := range dominantProperties(props) {
+		properties[prop.name] = prop.schema
+		if prop.required {
+			required = append(required, prop.name)
 		}
 	}
 
@@ -1003,42 +1026,20 @@ type structField struct {
 	enums        []interface{}
 	defaultValue interface{}
 	extensions   map[string]interface{}
+	pattern      string
+	// the exclusive bounds of gt and lt
+	exclusiveMaximum bool
+	exclusiveMinimum bool
+	// the validations of an array itself, the others are of its items
+	maxItems    *int64
+	minItems    *int64
+	uniqueItems bool
+	// goType the qualified type of the field or its items, e.g. time.Duration of []time.Duration
+	goType string
 }
 
+// parseStructField parses a field which is not flattened, the embedded fields are named by their types.
 func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[string]spec.Schema, []string, error) {
-	if field.Names == nil {
-		if field.Tag != nil {
-			skip, ok := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", "")).Lookup("swaggerignore")
-			if ok && strings.EqualFold(skip, "true") {
-				return nil, nil, nil
-			}
-		}
-
-		typeName, err := getFieldType(field.Type)
-		if err != nil {
-			return nil, nil, err
-		}
-		schema, err := parser.getTypeSchema(typeName, file, false)
-		if err != nil {
-			return nil, nil, err
-		}
-		if len(schema.Type) > 0 && schema.Type[0] == OBJECT {
-			if len(schema.Properties) == 0 {
-				return nil, nil, nil
-			}
-
-			properties := map[string]spec.Schema{}
-			for k, v := range schema.Properties {
-				properties[k] = v
-			}
-
-			return properties, schema.SchemaProps.Required, nil
-		}
-
-		// for alias type of non-struct types ,such as array,map, etc. ignore field tag.
-		return map[string]spec.Schema{typeName: *schema}, nil, nil
-	}
-
 	fieldName, schema, err := parser.getFieldName(field)
 	if err != nil {
 		return nil, nil, err
@@ -1058,14 +1059,18 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 		if err != nil {
 			return nil, nil, err
 		}
+		// the nil pointers are encoded as null
+		if _, ok := field.Type.(*ast.StarExpr); ok {
+			schema.AddExtension("x-nullable", true)
+		}
 	}
 
 	types := parser.GetSchemaTypePath(schema, 2)
 	if len(types) == 0 {
-		return nil, nil, fmt.Errorf("invalid type for field: %s", field.Names[0])
+		return nil, nil, fmt.Errorf("invalid type for field: %s", goFieldName(field))
 	}
 
-	structField, err := parser.parseFieldTag(field, types)
+	structField, err := parser.parseFieldTag(file, field, types)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1078,24 +1083,47 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 	schema.ReadOnly = structField.readOnly
 	schema.Default = structField.defaultValue
 	schema.Example = structField.exampleValue
-	if structField.schemaType != ARRAY {
+	// the format of the type is kept, e.g. date-time of time.Time
+	if structField.schemaType != ARRAY && structField.formatType != "" {
 		schema.Format = structField.formatType
 	}
-	schema.Extensions = structField.extensions
+	// the extensions of the schema are kept, e.g. x-nullable of sql.NullString, the tagged keys keep their case
+	if len(structField.extensions) > 0 && schema.Extensions == nil {
+		schema.Extensions = make(spec.Extensions, len(structField.extensions))
+	}
+	for key, value := range structField.extensions {
+		schema.Extensions[key] = value
+	}
 	eleSchema := schema
 	if structField.schemaType == ARRAY {
 		eleSchema = schema.Items.Schema
-		eleSchema.Format = structField.formatType
+		if structField.formatType != "" {
+			eleSchema.Format = structField.formatType
+		}
+	}
+	if structField.schemaType == ARRAY {
+		schema.MaxItems = structField.maxItems
+		schema.MinItems = structField.minItems
+		schema.UniqueItems = structField.uniqueItems
 	}
 	eleSchema.Maximum = structField.maximum
 	eleSchema.Minimum = structField.minimum
+	eleSchema.ExclusiveMaximum = structField.exclusiveMaximum
+	eleSchema.ExclusiveMinimum = structField.exclusiveMinimum
 	eleSchema.MultipleOf = structField.multipleOf
 	eleSchema.MaxLength = structField.maxLength
 	eleSchema.MinLength = structField.minLength
-	eleSchema.Enum = structField.enums
+	eleSchema.Pattern = structField.pattern
+	// the enums of the tags take precedence over the constants of the type
+	if structField.enums != nil {
+		eleSchema.Enum = structField.enums
+		delete(eleSchema.Extensions, EnumVarNamesExtension)
+		delete(eleSchema.Extensions, EnumDescriptionsExtension)
+	}
 
 	var tagRequired []string
-	if structField.isRequired {
+	// the fields without omitempty are always encoded
+	if structField.isRequired || parser.RequiredByDefault && !hasJSONOption(field, "omitempty") {
 		tagRequired = append(tagRequired, fieldName)
 	}
 
@@ -1121,6 +1149,20 @@ func getFieldType(field ast.Expr) (string, error) {
 		}
 
 		return fullName, nil
+	case *ast.IndexExpr:
+		name, err := getFieldType(fieldType.X)
+		if err != nil {
+			return "", err
+		}
+
+		return genericTypeName(name, []ast.Expr{fieldType.Index})
+	case *ast.IndexListExpr:
+		name, err := getFieldType(fieldType.X)
+		if err != nil {
+			return "", err
+		}
+
+		return genericTypeName(name, fieldType.Indices)
 	default:
 		return "", fmt.Errorf("unknown field type %#v", field)
 	}
@@ -1128,7 +1170,8 @@ func getFieldType(field ast.Expr) (string, error) {
 
 func (parser *Parser) getFieldName(field *ast.Field) (name string, schema *spec.Schema, err error) {
 	// Skip non-exported fields.
-	if !ast.IsExported(field.Names[0].Name) {
+	goName := goFieldName(field)
+	if !ast.IsExported(goName) {
 		return "", nil, nil
 	}
 
@@ -1140,11 +1183,11 @@ func (parser *Parser) getFieldName(field *ast.Field) (name string, schema *spec.
 			return "", nil, nil
 		}
 
-		name = structTag.Get("json")
-		// json:"tag,hoge"
-		if name = strings.TrimSpace(strings.Split(name, ",")[0]); name == "-" {
+		// json:"tag,hoge", the field named - is json:"-,"
+		if tag := structTag.Get("json"); tag == "-" {
 			return "", nil, nil
 		}
+		name, _ = jsonTag(field)
 
 		typeTag := structTag.Get("swaggertype")
 		if typeTag != "" {
@@ -1159,21 +1202,68 @@ func (parser *Parser) getFieldName(field *ast.Field) (name string, schema *spec.
 	if name == "" {
 		switch parser.PropNamingStrategy {
 		case SnakeCase:
-			name = toSnakeCase(field.Names[0].Name)
+			name = toSnakeCase(goName)
 		case PascalCase:
-			name = field.Names[0].Name
+			name = goName
 		default:
-			name = toLowerCamelCase(field.Names[0].Name)
+			name = toLowerCamelCase(goName)
 		}
 	}
 
 	return name, schema, err
 }
 
-func (parser *Parser) parseFieldTag(field *ast.Field, types []string) (*structField, error) {
+// paramFieldNames maps the property names of the fields of a struct to their names in the tag, e.g. uri:"id",
+// the fields without the tag are not in the map, and the fields skipped by the tag are mapped to empty.
+func (parser *Parser) paramFieldNames(typeName string, file *ast.File, tag string) map[string]string {
+	names := make(map[string]string)
+	typeSpecDef := parser.packages.FindTypeSpec(typeName, file, parser.ParseDependency)
+	if typeSpecDef == nil || tag == "" {
+		return names
+	}
+	structType, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType)
+	if !ok || structType.Fields == nil {
+		return names
+	}
+
+	for _, field := range structType.Fields.List {
+		if isFlattened(field) {
+			// the fields of an embedded struct are the fields of the struct
+			if embedded, err := getFieldType(field.Type); err == nil {
+				for name, tagName := range parser.paramFieldNames(embedded, typeSpecDef.File, tag) {
+					names[name] = tagName
+				}
+			}
+			continue
+		}
+		if field.Tag == nil {
+			continue
+		}
+		tagName, exist := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", "")).Lookup(tag)
+		if !exist {
+			continue
+		}
+		name, _, err := parser.getFieldName(field)
+		if err != nil || name == "" {
+			continue
+		}
+		switch tagName = strings.TrimSpace(strings.Split(tagName, ",")[0]); tagName {
+		case "":
+		case "-":
+			names[name] = ""
+		default:
+			names[name] = tagName
+		}
+	}
+
+	return names
+}
+
+func (parser *Parser) parseFieldTag(file *ast.File, field *ast.Field, types []string) (*structField, error) {
 	structField := &structField{
 		//    name:       field.Names[0].Name,
 		schemaType: types[0],
+		goType:     parser.elementTypeName(file, field.Type),
 	}
 	if len(types) > 1 && (types[0] == ARRAY || types[0] == OBJECT) {
 		structField.arrayType = types[1]
@@ -1210,26 +1300,6 @@ func (parser *Parser) parseFieldTag(field *ast.Field, types []string) (*structFi
 	if formatTag != "" {
 		structField.formatType = formatTag
 	}
-	bindingTag := structTag.Get("binding")
-	if bindingTag != "" {
-		for _, val := range strings.Split(bindingTag, ",") {
-			if val == "required" {
-				structField.isRequired = true
-
-				break
-			}
-		}
-	}
-	validateTag := structTag.Get("validate")
-	if validateTag != "" {
-		for _, val := range strings.Split(validateTag, ",") {
-			if val == "required" {
-				structField.isRequired = true
-
-				break
-			}
-		}
-	}
 	extensionsTag := structTag.Get("extensions")
 	if extensionsTag != "" {
 		structField.extensions = map[string]interface{}{}
@@ -1302,6 +1372,10 @@ func (parser *Parser) parseFieldTag(field *ast.Field, types []string) (*structFi
 		}
 		structField.minLength = minLength
 	}
+	// the validations of go-playground/validator, the tags above take precedence
+	for _, tagName := range []string{"binding", "validate"} {
+		structField.parseValidateTag(goFieldName(field), structTag.Get(tagName))
+	}
 	readOnly := structTag.Get("readonly")
 	if readOnly != "" {
 		structField.readOnly = readOnly == "true"
@@ -1333,6 +1407,277 @@ func (parser *Parser) parseFieldTag(field *ast.Field, types []string) (*structFi
 	return structField, nil
 }
 
+// validatorFormats the formats of the validations of go-playground/validator.
+var validatorFormats = map[string]string{
+	"email":            "email",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"url":              "uri",
+	"uri":              "uri",
+	"http_url":         "uri",
+	"ip":               "ip",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"hostname":         "hostname",
+	"base64":           "byte",
+	"e164":             "e164",
+	"mac":              "mac",
+	"cidr":             "cidr",
+	"json":             "json",
+	"jwt":              "jwt",
+	"rgb":              "rgb",
+	"hexcolor":         "hexcolor",
+	"isbn":             "isbn",
+	"iso3166_1_alpha2": "iso3166-1-alpha-2",
+}
+
+// validatorPatterns the patterns of the validations of go-playground/validator.
+var validatorPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"lowercase":   `^[^A-Z]*$`,
+	"uppercase":   `^[^a-z]*$`,
+}
+
+// parseValidateTag sets the validations of a binding or validate tag of go-playground/validator,
+// e.g. binding:"required,min=1,max=10", the rules after dive are the validations of the items of an array.
+// The validations set by the tags of swag, e.g. maximum:"10", are kept, the rules whose values can not be parsed
+// are skipped, e.g. max=1h of a string.
+func (sf *structField) parseValidateTag(fieldName, tag string) {
+	schemaType, items := sf.schemaType, false
+	keys := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		// the comma and the pipe in the values are escaped
+		value = strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(value)
+
+		switch {
+		case keys:
+			keys = name != "endkeys"
+			continue
+		case name == "keys":
+			keys = true
+			continue
+		case name == "dive":
+			// only the items of the arrays are documented
+			if items || sf.schemaType != ARRAY {
+				return
+			}
+			schemaType, items = sf.arrayType, true
+			continue
+		// the alternatives can not be documented
+		case strings.Contains(rule, "|"):
+			continue
+		}
+
+		var err error
+		switch name {
+		case "required":
+			if !items {
+				sf.isRequired = true
+			}
+		// the conditions of the validations, the field is not required by the schema
+		case "omitempty", "required_if", "required_unless", "required_with", "required_with_all",
+			"required_without", "required_without_all":
+		case "min", "gte":
+			err = sf.setMinimum(schemaType, items, value, false)
+		case "max", "lte":
+			err = sf.setMaximum(schemaType, items, value, false)
+		case "gt":
+			err = sf.setMinimum(schemaType, items, value, true)
+		case "lt":
+			err = sf.setMaximum(schemaType, items, value, true)
+		case "len", "eq":
+			if name == "eq" && !IsNumericType(schemaType) {
+				continue
+			}
+			if err = sf.setMinimum(schemaType, items, value, false); err == nil {
+				err = sf.setMaximum(schemaType, items, value, false)
+			}
+		case "oneof":
+			if sf.enums == nil && (schemaType == STRING || IsNumericType(schemaType)) {
+				var enums []interface{}
+				for _, item := range splitOneOf(value) {
+					var enum interface{}
+					if enum, err = defineType(schemaType, item); err != nil {
+						break
+					}
+					enums = append(enums, enum)
+				}
+				if err == nil {
+					sf.enums = enums
+				}
+			}
+		case "unique":
+			if schemaType == ARRAY && !items {
+				sf.uniqueItems = true
+			}
+		case "datetime":
+			if sf.formatType == "" && schemaType == STRING {
+				sf.formatType = "date-time"
+				if value == "2006-01-02" {
+					sf.formatType = "date"
+				}
+			}
+		case "startswith", "endswith", "contains":
+			if sf.pattern == "" && schemaType == STRING {
+				sf.pattern = regexp.QuoteMeta(value)
+				if name == "startswith" {
+					sf.pattern = "^" + sf.pattern
+				} else if name == "endswith" {
+					sf.pattern += "$"
+				}
+			}
+		default:
+			if format, exist := validatorFormats[name]; exist && sf.formatType == "" && schemaType == STRING {
+				sf.formatType = format
+			}
+			if pattern, exist := validatorPatterns[name]; exist && sf.pattern == "" && schemaType == STRING {
+				sf.pattern = pattern
+			}
+		}
+		if err != nil {
+			log.Printf("[WARNING] skip the validation %q of field %s, can't parse its value: %v\n", rule, fieldName, err)
+		}
+	}
+}
+
+// setMinimum sets the minimum, the min length or the min items of the schema type by the value of a validation,
+// the minimum is exclusive if exclusive, e.g. gt=0.
+func (sf *structField) setMinimum(schemaType string, items bool, value string, exclusive bool) error {
+	switch {
+	case schemaType == ARRAY && !items:
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		if exclusive {
+			count++
+		}
+		if sf.minItems == nil {
+			sf.minItems = &count
+		}
+	case sf.goType == "time.Time":
+		// the bounds of the times are not documented, e.g. gt for after the current time
+	case schemaType == STRING:
+		length, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		if exclusive {
+			length++
+		}
+		if sf.minLength == nil {
+			sf.minLength = &length
+		}
+	case IsNumericType(schemaType):
+		minimum, err := sf.parseBound(value)
+		if err != nil {
+			return err
+		}
+		if sf.minimum == nil {
+			sf.minimum = &minimum
+			sf.exclusiveMinimum = exclusive
+		}
+	}
+
+	return nil
+}
+
+// setMaximum sets the maximum, the max length or the max items of the schema type by the value of a validation,
+// the maximum is exclusive if exclusive, e.g. lt=10.
+func (sf *structField) setMaximum(schemaType string, items bool, value string, exclusive bool) error {
+	switch {
+	case schemaType == ARRAY && !items:
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		if exclusive {
+			count--
+		}
+		if sf.maxItems == nil {
+			sf.maxItems = &count
+		}
+	case sf.goType == "time.Time":
+	case schemaType == STRING:
+		length, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		if exclusive {
+			length--
+		}
+		if sf.maxLength == nil {
+			sf.maxLength = &length
+		}
+	case IsNumericType(schemaType):
+		maximum, err := sf.parseBound(value)
+		if err != nil {
+			return err
+		}
+		if sf.maximum == nil {
+			sf.maximum = &maximum
+			sf.exclusiveMaximum = exclusive
+		}
+	}
+
+	return nil
+}
+
+// parseBound parses the value of a bound of a number, the bounds of time.Duration are durations, e.g. max=1h.
+func (sf *structField) parseBound(value string) (float64, error) {
+	if sf.goType == "time.Duration" {
+		duration, err := time.ParseDuration(value)
+		return float64(duration), err
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// elementTypeName returns the type of a field or its items qualified by the import path, e.g. time.Duration of
+// []*time.Duration, empty if the type is not named.
+func (parser *Parser) elementTypeName(file *ast.File, expr ast.Expr) string {
+	for {
+		switch typeExpr := expr.(type) {
+		case *ast.StarExpr:
+			expr = typeExpr.X
+		case *ast.ArrayType:
+			expr = typeExpr.Elt
+		default:
+			typeName, err := getFieldType(expr)
+			if err != nil {
+				return ""
+			}
+			return parser.packages.qualifiedTypeName(typeName, file)
+		}
+	}
+}
+
+// splitOneOf splits the values of oneof separated by spaces, a value containing spaces is quoted, e.g. 'a b' c.
+func splitOneOf(value string) []string {
+	values := make([]string, 0)
+	for value = strings.TrimSpace(value); value != ""; value = strings.TrimSpace(value) {
+		if value[0] == '\'' {
+			if end := strings.IndexByte(value[1:], '\''); end >= 0 {
+				values = append(values, value[1:end+1])
+				value = value[end+2:]
+				continue
+			}
+		}
+		item, rest, _ := strings.Cut(value, " ")
+		values = append(values, item)
+		value = rest
+	}
+
+	return values
+}
+
 // GetSchemaTypePath get path of schema type.
 func (parser *Parser) GetSchemaTypePath(schema *spec.Schema, depth int) []string {
 	if schema == nil || depth == 0 {
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/spec"
+)
+
+// generateFileComments loads the package in dir and generates the comments of its handlers for routes, keyed by
+// the names of the handlers, e.g. nethttp.CreateUser.
+func generateFileComments(t *testing.T, dir string, routes []RouteInfo) map[string][]string {
+	t.Helper()
+
+	fileSet := token.NewFileSet()
+	pkgs, err := loadPackages(fileSet, []string{dir}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := make(funcIndex)
+	index.addPackages(fileSet, pkgs)
+	routeInfos := GetRouteInfos(RouteSourceFunc(func() []RouteInfo { return routes }))
+
+	comments := make(map[string][]string)
+	for _, tree := range pkgs[0].Syntax {
+		name := filepath.Base(fileSet.Position(tree.Package).Filename)
+		file, err := parseFileAST(name, tree, fileSet, pkgs[0].TypesInfo, index, routeInfos, []string{GinFilter, HTTPFilter}, DefaultCallDepth, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, texts := range generatedComments(file.Functions) {
+			comments[key] = texts
+		}
+	}
+
+	return comments
+}
+
+// newTypesParser loads the packages in dirs and collects their types, it returns the parser and a file of the
+// package in the first dir.
+func newTypesParser(t *testing.T, dirs ...string) (*Parser, *ast.File) {
+	t.Helper()
+
+	pkgs, err := loadPackages(token.NewFileSet(), dirs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := New()
+	parser.packages.cachePackages(pkgs...)
+	for index, pkg := range pkgs {
+		if err := parser.getAllGoFileInfo(pkg.PkgPath, dirs[index]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if parser.parsedSchemas, err = parser.packages.ParseTypes(); err != nil {
+		t.Fatal(err)
+	}
+
+	var file *ast.File
+	for _, file = range parser.packages.packages[pkgs[0].PkgPath].Files {
+		break
+	}
+
+	return parser, file
+}
+
+// parseTypeSchemas loads the package in dir and parses the schemas of its types by names, the schemas of the structs
+// are inlined.
+func parseTypeSchemas(t *testing.T, dir string, names ...string) map[string]*spec.Schema {
+	t.Helper()
+
+	parser, file := newTypesParser(t, dir)
+	schemas := make(map[string]*spec.Schema, len(names))
+	for _, name := range names {
+		schema, err := parser.getTypeSchema(file.Name.Name+"."+name, file, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schemas[name] = schema
+	}
+
+	return schemas
+}
+
+func TestParseValidateTag(t *testing.T) {
+	int64Ptr := func(value int64) *int64 { return &value }
+	float64Ptr := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name     string
+		field    structField
+		tag      string
+		expected structField
+	}{
+		{
+			name:  "string lengths",
+			field: structField{schemaType: STRING},
+			tag:   "required,min=1,max=10",
+			expected: structField{
+				schemaType: STRING,
+				isRequired: true,
+				minLength:  int64Ptr(1),
+				maxLength:  int64Ptr(10),
+			},
+		},
+		{
+			name:  "exclusive number bounds",
+			field: structField{schemaType: NUMBER},
+			tag:   "gt=0,lt=1.5",
+			expected: structField{
+				schemaType:       NUMBER,
+				minimum:          float64Ptr(0),
+				exclusiveMinimum: true,
+				maximum:          float64Ptr(1.5),
+				exclusiveMaximum: true,
+			},
+		},
+		{
+			name:  "exclusive string lengths",
+			field: structField{schemaType: STRING},
+			tag:   "gt=0,lt=5",
+			expected: structField{
+				schemaType: STRING,
+				minLength:  int64Ptr(1),
+				maxLength:  int64Ptr(4),
+			},
+		},
+		{
+			name:  "array items",
+			field: structField{schemaType: ARRAY, arrayType: INTEGER},
+			tag:   "len=3,unique",
+			expected: structField{
+				schemaType:  ARRAY,
+				arrayType:   INTEGER,
+				minItems:    int64Ptr(3),
+				maxItems:    int64Ptr(3),
+				uniqueItems: true,
+			},
+		},
+		{
+			name:  "dive into the items",
+			field: structField{schemaType: ARRAY, arrayType: INTEGER},
+			tag:   "max=5,dive,min=1",
+			expected: structField{
+				schemaType: ARRAY,
+				arrayType:  INTEGER,
+				maxItems:   int64Ptr(5),
+				minimum:    float64Ptr(1),
+			},
+		},
+		{
+			name:  "oneof",
+			field: structField{schemaType: STRING},
+			tag:   "oneof=a 'b c' d",
+			expected: structField{
+				schemaType: STRING,
+				enums:      []interface{}{"a", "b c", "d"},
+			},
+		},
+		{
+			name:     "oneof not of the type",
+			field:    structField{schemaType: INTEGER},
+			tag:      "oneof=1 x",
+			expected: structField{schemaType: INTEGER},
+		},
+		{
+			name:  "formats",
+			field: structField{schemaType: STRING},
+			tag:   "omitempty,email",
+			expected: structField{
+				schemaType: STRING,
+				formatType: "email",
+			},
+		},
+		{
+			name:     "alternatives",
+			field:    structField{schemaType: STRING},
+			tag:      "email|url",
+			expected: structField{schemaType: STRING},
+		},
+		{
+			name:     "bounds of a boolean",
+			field:    structField{schemaType: BOOLEAN},
+			tag:      "min=1,max=2",
+			expected: structField{schemaType: BOOLEAN},
+		},
+		{
+			name:  "time without a value",
+			field: structField{schemaType: STRING, goType: "time.Time"},
+			tag:   "required,gt",
+			expected: structField{
+				schemaType: STRING,
+				goType:     "time.Time",
+				isRequired: true,
+			},
+		},
+		{
+			name:  "duration",
+			field: structField{schemaType: INTEGER, goType: "time.Duration"},
+			tag:   "min=1s,max=1h",
+			expected: structField{
+				schemaType: INTEGER,
+				goType:     "time.Duration",
+				minimum:    float64Ptr(float64(time.Second)),
+				maximum:    float64Ptr(float64(time.Hour)),
+			},
+		},
+		{
+			name:     "number without a value",
+			field:    structField{schemaType: INTEGER},
+			tag:      "gt",
+			expected: structField{schemaType: INTEGER},
+		},
+		{
+			name:  "number not parsed",
+			field: structField{schemaType: INTEGER},
+			tag:   "min=abc,max=10",
+			expected: structField{
+				schemaType: INTEGER,
+				maximum:    float64Ptr(10),
+			},
+		},
+		{
+			name:  "kept tags",
+			field: structField{schemaType: INTEGER, maximum: float64Ptr(3)},
+			tag:   "max=10",
+			expected: structField{
+				schemaType: INTEGER,
+				maximum:    float64Ptr(3),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := test.field
+			field.parseValidateTag("Field", test.tag)
+			if !reflect.DeepEqual(field, test.expected) {
+				t.Errorf("parseValidateTag(%q) = %+v, expected %+v", test.tag, field, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenameSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkgPath  string
+		expected string
+	}{
+		{name: "models.User", pkgPath: "github.com/x/order/models", expected: "github.com_x_order_models.User"},
+		{name: "User", pkgPath: "github.com/x/models", expected: "github.com_x_models.User"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := New().renameSchema(test.name, test.pkgPath); got != test.expected {
+				t.Errorf("renameSchema(%q, %q) = %q, expected %q", test.name, test.pkgPath, got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// RouteInfo represents a request route's specification which contains method, path and its handler.
+type RouteInfo struct {
+	Method string
+	Path   string
+	// Handler symbol of the handler, e.g. main.SayHello or github.com/x/api.(*Controller).Get-fm
+	Handler string
+	// Middlewares symbols of the middlewares before the handler, only found by StaticRoutes,
+	// e.g. github.com/gin-gonic/gin.BasicAuth for gin.BasicAuth(accounts)
+	Middlewares []string `json:",omitempty"`
+	// Group prefix of the router group the route is registered on, e.g. /api/v1/users, only found by StaticRoutes
+	Group string `json:",omitempty"`
+	// Tags of the route, derived from Group if empty
+	Tags []string `json:",omitempty"`
+	// Security names of the security definitions required by the route, derived from Middlewares if empty
+	Security []string `json:",omitempty"`
+}
+
+// DefaultSecurityMiddlewares the auth middlewares of gin keyed by symbol and the security definitions they require.
+var DefaultSecurityMiddlewares = map[string]string{
+	"github.com/gin-gonic/gin.BasicAuth":         "BasicAuth",
+	"github.com/gin-gonic/gin.BasicAuthForRealm": "BasicAuth",
+	"github.com/gin-gonic/gin.BasicAuthForProxy": "BasicAuth",
+}
+
+// RouteSource lists the routes registered in a router of any framework.
+type RouteSource interface {
+	Routes() []RouteInfo
+}
+
+// RouteSourceFunc an adapter to allow the use of ordinary functions as RouteSource.
+type RouteSourceFunc func() []RouteInfo
+
+// Routes calls f().
+func (f RouteSourceFunc) Routes() []RouteInfo {
+	return f()
+}
+
+// GetRouteInfos returns the routes of source keyed by their handler, a handler may be registered on several routes.
+func GetRouteInfos(source RouteSource) map[string][]RouteInfo {
+	routes := make(map[string][]RouteInfo)
+	if source == nil {
+		return routes
+	}
+	for _, info := range source.Routes() {
+		routes[info.Handler] = append(routes[info.Handler], info)
+	}
+	return routes
+}
+
+// flattenRoutes returns all the routes sorted by handler, path and method.
+func flattenRoutes(routeInfos map[string][]RouteInfo) []RouteInfo {
+	routes := make([]RouteInfo, 0, len(routeInfos))
+	for _, infos := range routeInfos {
+		routes = append(routes, infos...)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Handler != routes[j].Handler {
+			return routes[i].Handler < routes[j].Handler
+		}
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes
+}
+
+// groupRoutes derives the tags and the security of the routes from their groups and middlewares, securities maps
+// the middlewares to the security definitions. The paths are made relative to the base path shared by the groups
+// of all the routes, which is returned, e.g. /api/v1 for the groups /api/v1/users and /api/v1/orders.
+func groupRoutes(routeInfos map[string][]RouteInfo, securities map[string]string) string {
+	basePath := sharedBasePath(routeInfos)
+
+	for _, infos := range routeInfos {
+		for index := range infos {
+			groupRoute(&infos[index], basePath, securities)
+		}
+	}
+
+	return basePath
+}
+
+func groupRoute(info *RouteInfo, basePath string, securities map[string]string) {
+	if len(info.Tags) == 0 {
+		if tag := groupTag(strings.TrimPrefix(info.Group, basePath)); tag != "" {
+			info.Tags = []string{tag}
+		}
+	}
+	if len(info.Security) == 0 {
+		for _, middleware := range info.Middlewares {
+			name, exist := securities[middleware]
+			if !exist || containsString(info.Security, name) {
+				continue
+			}
+			info.Security = append(info.Security, name)
+		}
+	}
+	if basePath != "" {
+		info.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(info.Path, basePath), "/")
+	}
+}
+
+// sharedBasePath returns the longest prefix shared by the groups of all the routes, empty if there is none.
+func sharedBasePath(routeInfos map[string][]RouteInfo) string {
+	var shared []string
+	for _, info := range flattenRoutes(routeInfos) {
+		if info.Group == "" {
+			return ""
+		}
+		segments := strings.FieldsFunc(info.Group, func(r rune) bool { return r == '/' })
+		if shared == nil {
+			shared = segments
+			continue
+		}
+		length := 0
+		for length < len(shared) && length < len(segments) && shared[length] == segments[length] {
+			length++
+		}
+		shared = shared[:length]
+	}
+
+	for index, segment := range shared {
+		// the path params of the groups can not be a part of the base path
+		if strings.ContainsAny(segment[:1], ":*{") {
+			shared = shared[:index]
+			break
+		}
+	}
+	if len(shared) == 0 {
+		return ""
+	}
+
+	return "/" + strings.Join(shared, "/")
+}
+
+// groupTag returns the last segment of a group prefix which is not a path param, e.g. orders for /users/:id/orders/:orderId.
+func groupTag(group string) string {
+	segments := strings.FieldsFunc(group, func(r rune) bool { return r == '/' })
+	for index := len(segments) - 1; index >= 0; index-- {
+		if !strings.ContainsAny(segments[index][:1], ":*{") {
+			return segments[index]
+		}
+	}
+
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GinRoutes returns the routes registered in a gin engine, the engine must have registered its routes.
+func GinRoutes(app *gin.Engine) RouteSource {
+	return RouteSourceFunc(func() []RouteInfo {
+		routes := make([]RouteInfo, 0)
+		for _, info := range app.Routes() {
+			routes = append(routes, RouteInfo{
+				Method:  info.Method,
+				Path:    info.Path,
+				Handler: info.Handler,
+			})
+		}
+		return routes
+	})
+}
+
+// ChiRoutes returns the routes registered in a chi router, mounted sub routers are included.
+func ChiRoutes(router chi.Routes) RouteSource {
+	return RouteSourceFunc(func() []RouteInfo {
+		routes := make([]RouteInfo, 0)
+		_ = chi.Walk(router, func(method string, route string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+			routes = append(routes, RouteInfo{
+				Method:  method,
+				Path:    route,
+				Handler: handlerName(handler),
+			})
+			return nil
+		})
+		return routes
+	})
+}
+
+// EchoRoutes returns the routes registered in an echo instance.
+func EchoRoutes(app *echo.Echo) RouteSource {
+	return RouteSourceFunc(func() []RouteInfo {
+		routes := make([]RouteInfo, 0)
+		for _, info := range app.Routes() {
+			// echo registers its own handlers for not found and method not allowed
+			if info.Method == echo.RouteNotFound {
+				continue
+			}
+			routes = append(routes, RouteInfo{
+				Method:  info.Method,
+				Path:    info.Path,
+				Handler: info.Name,
+			})
+		}
+		return routes
+	})
+}
+
+// ServeMuxRoutes records the routes registered to a http.ServeMux, which can not list its routes itself.
+// Patterns may contain a method, e.g. "GET /users/{id}", patterns without a method are recorded as GET.
+type ServeMuxRoutes struct {
+	*http.ServeMux
+
+	routes []RouteInfo
+}
+
+// NewServeMuxRoutes wraps mux, routes must be registered by the returned ServeMuxRoutes to be recorded.
+func NewServeMuxRoutes(mux *http.ServeMux) *ServeMuxRoutes {
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+
+	return &ServeMuxRoutes{
+		ServeMux: mux,
+		routes:   make([]RouteInfo, 0),
+	}
+}
+
+// Handle registers the handler for the given pattern.
+func (mux *ServeMuxRoutes) Handle(pattern string, handler http.Handler) {
+	mux.record(pattern, handler)
+	mux.ServeMux.Handle(pattern, handler)
+}
+
+// HandleFunc registers the handler function for the given pattern.
+func (mux *ServeMuxRoutes) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	mux.record(pattern, handler)
+	mux.ServeMux.HandleFunc(pattern, handler)
+}
+
+// Routes returns the recorded routes.
+func (mux *ServeMuxRoutes) Routes() []RouteInfo {
+	return mux.routes
+}
+
+func (mux *ServeMuxRoutes) record(pattern string, handler interface{}) {
+	method, path := splitPattern(pattern)
+
+	mux.routes = append(mux.routes, RouteInfo{
+		Method:  method,
+		Path:    path,
+		Handler: handlerName(handler),
+	})
+}
+
+// splitPattern splits a ServeMux pattern into its method and path, GET if the pattern has no method.
+func splitPattern(pattern string) (string, string) {
+	method, path := http.MethodGet, strings.TrimSpace(pattern)
+	if fields := strings.Fields(path); len(fields) == 2 {
+		method, path = strings.ToUpper(fields[0]), fields[1]
+	}
+	// drop the host of the pattern
+	if index := strings.Index(path, "/"); index > 0 {
+		path = path[index:]
+	}
+
+	return method, path
+}
+
+// handlerName returns the symbol of a handler in the format of runtime, the same as gin.RouteInfo.Handler.
+func handlerName(handler interface{}) string {
+	value := reflect.ValueOf(handler)
+	if value.Kind() == reflect.Func {
+		if fn := runtime.FuncForPC(value.Pointer()); fn != nil {
+			return fn.Name()
+		}
+		return ""
+	}
+
+	handlerType := reflect.TypeOf(handler)
+	if handlerType == nil {
+		return ""
+	}
+	if handlerType.Kind() == reflect.Ptr {
+		handlerType = handlerType.Elem()
+		return handlerType.PkgPath() + ".(*" + handlerType.Name() + ").ServeHTTP"
+	}
+
+	return handlerType.PkgPath() + "." + handlerType.Name() + ".ServeHTTP"
+}
@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const testPackage = "github.com/Scterl/go-swagger/parser."
+
+func listUsers(w http.ResponseWriter, r *http.Request) {}
+
+func listGinUsers(c *gin.Context) {}
+
+func listEchoUsers(c echo.Context) error { return nil }
+
+type usersHandler struct{}
+
+func (usersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func TestRouteSources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		source   func() RouteSource
+		expected []RouteInfo
+	}{
+		{
+			name: "gin",
+			source: func() RouteSource {
+				app := gin.New()
+				app.GET("/users/:id", listGinUsers)
+				return GinRoutes(app)
+			},
+			expected: []RouteInfo{{Method: "GET", Path: "/users/:id", Handler: testPackage + "listGinUsers"}},
+		},
+		{
+			name: "chi",
+			source: func() RouteSource {
+				router := chi.NewRouter()
+				router.Route("/api", func(r chi.Router) {
+					r.Get("/users/{id}", listUsers)
+				})
+				return ChiRoutes(router)
+			},
+			expected: []RouteInfo{{Method: "GET", Path: "/api/users/{id}", Handler: testPackage + "listUsers"}},
+		},
+		{
+			name: "echo",
+			source: func() RouteSource {
+				app := echo.New()
+				app.POST("/users", listEchoUsers)
+				return EchoRoutes(app)
+			},
+			expected: []RouteInfo{{Method: "POST", Path: "/users", Handler: testPackage + "listEchoUsers"}},
+		},
+		{
+			name: "net/http",
+			source: func() RouteSource {
+				mux := NewServeMuxRoutes(nil)
+				mux.HandleFunc("GET /users/{id}", listUsers)
+				mux.Handle("/teams", usersHandler{})
+				mux.Handle("example.com/orders", &usersHandler{})
+				return mux
+			},
+			expected: []RouteInfo{
+				{Method: "GET", Path: "/users/{id}", Handler: testPackage + "listUsers"},
+				{Method: "GET", Path: "/teams", Handler: testPackage + "usersHandler.ServeHTTP"},
+				{Method: "GET", Path: "/orders", Handler: testPackage + "(*usersHandler).ServeHTTP"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.source().Routes(); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Routes() = %+v, expected %+v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestGetRouteInfos(t *testing.T) {
+	routeInfos := GetRouteInfos(RouteSourceFunc(func() []RouteInfo {
+		return []RouteInfo{
+			{Method: "GET", Path: "/users", Handler: "main.ListUsers"},
+			{Method: "GET", Path: "/v1/users", Handler: "main.ListUsers"},
+			{Method: "POST", Path: "/users", Handler: "main.CreateUser"},
+		}
+	}))
+
+	handlers := make([]string, 0, len(routeInfos))
+	for handler := range routeInfos {
+		handlers = append(handlers, handler)
+	}
+	sort.Strings(handlers)
+	if expected := []string{"main.CreateUser", "main.ListUsers"}; !reflect.DeepEqual(handlers, expected) {
+		t.Errorf("handlers = %q, expected %q", handlers, expected)
+	}
+	if len(routeInfos["main.ListUsers"]) != 2 {
+		t.Errorf("routes of main.ListUsers = %+v, expected 2 routes", routeInfos["main.ListUsers"])
+	}
+	if len(GetRouteInfos(nil)) != 0 {
+		t.Error("GetRouteInfos(nil) returns routes")
+	}
+}
+
+func TestSplitPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		path    string
+	}{
+		{pattern: "/users/{id}", method: "GET", path: "/users/{id}"},
+		{pattern: "POST /users", method: "POST", path: "/users"},
+		{pattern: "delete /users/{id}", method: "DELETE", path: "/users/{id}"},
+		{pattern: "example.com/users/", method: "GET", path: "/users/"},
+		{pattern: "PUT example.com/users/{id}", method: "PUT", path: "/users/{id}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			method, path := splitPattern(test.pattern)
+			if method != test.method || path != test.path {
+				t.Errorf("splitPattern(%q) = %s %s, expected %s %s", test.pattern, method, path, test.method, test.path)
+			}
+		})
+	}
+}
+
+func TestGroupRoutes(t *testing.T) {
+	securities := map[string]string{"github.com/x/auth.JWT": "JWT"}
+
+	tests := []struct {
+		name     string
+		routes   []RouteInfo
+		basePath string
+		expected []RouteInfo
+	}{
+		{
+			name: "shared base path",
+			routes: []RouteInfo{
+				{Method: "GET", Path: "/api/v1/users/:id", Handler: "main.GetUser", Group: "/api/v1/users", Middlewares: []string{"github.com/x/auth.JWT", "github.com/x/log.Logger"}},
+				{Method: "GET", Path: "/api/v1/orders/:id/items", Handler: "main.ListItems", Group: "/api/v1/orders/:id/items"},
+			},
+			basePath: "/api/v1",
+			expected: []RouteInfo{
+				{Method: "GET", Path: "/users/:id", Handler: "main.GetUser", Group: "/api/v1/users", Middlewares: []string{"github.com/x/auth.JWT", "github.com/x/log.Logger"}, Tags: []string{"users"}, Security: []string{"JWT"}},
+				{Method: "GET", Path: "/orders/:id/items", Handler: "main.ListItems", Group: "/api/v1/orders/:id/items", Tags: []string{"items"}},
+			},
+		},
+		{
+			name: "path param in the groups",
+			routes: []RouteInfo{
+				{Method: "GET", Path: "/:tenant/users", Handler: "main.ListUsers", Group: "/:tenant/users"},
+				{Method: "GET", Path: "/:tenant/orders", Handler: "main.ListOrders", Group: "/:tenant/orders"},
+			},
+			basePath: "",
+			expected: []RouteInfo{
+				{Method: "GET", Path: "/:tenant/orders", Handler: "main.ListOrders", Group: "/:tenant/orders", Tags: []string{"orders"}},
+				{Method: "GET", Path: "/:tenant/users", Handler: "main.ListUsers", Group: "/:tenant/users", Tags: []string{"users"}},
+			},
+		},
+		{
+			name: "route without a group",
+			routes: []RouteInfo{
+				{Method: "GET", Path: "/api/users", Handler: "main.ListUsers", Group: "/api/users"},
+				{Method: "GET", Path: "/ping", Handler: "main.Ping"},
+			},
+			basePath: "",
+			expected: []RouteInfo{
+				{Method: "GET", Path: "/api/users", Handler: "main.ListUsers", Group: "/api/users", Tags: []string{"users"}},
+				{Method: "GET", Path: "/ping", Handler: "main.Ping"},
+			},
+		},
+		{
+			name: "tags and security set already",
+			routes: []RouteInfo{
+				{Method: "GET", Path: "/users", Handler: "main.ListUsers", Group: "/users", Middlewares: []string{"github.com/x/auth.JWT"}, Tags: []string{"accounts"}, Security: []string{"OAuth2"}},
+			},
+			basePath: "/users",
+			expected: []RouteInfo{
+				{Method: "GET", Path: "/", Handler: "main.ListUsers", Group: "/users", Middlewares: []string{"github.com/x/auth.JWT"}, Tags: []string{"accounts"}, Security: []string{"OAuth2"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			routeInfos := GetRouteInfos(RouteSourceFunc(func() []RouteInfo { return test.routes }))
+			if basePath := groupRoutes(routeInfos, securities); basePath != test.basePath {
+				t.Errorf("groupRoutes base path = %q, expected %q", basePath, test.basePath)
+			}
+			if got := flattenRoutes(routeInfos); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("groupRoutes = %+v, expected %+v", got, test.expected)
+			}
+		})
+	}
+}
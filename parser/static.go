@@ -0,0 +1,596 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ginRouters the types whose methods register gin routes.
+var ginRouters = map[string]bool{
+	"*github.com/gin-gonic/gin.Engine":      true,
+	"*github.com/gin-gonic/gin.RouterGroup": true,
+	"github.com/gin-gonic/gin.IRouter":      true,
+	"github.com/gin-gonic/gin.IRoutes":      true,
+}
+
+// ginAnyMethods the methods registered by gin's Any.
+var ginAnyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodHead,
+	http.MethodOptions, http.MethodDelete, http.MethodConnect, http.MethodTrace,
+}
+
+// ginDefaultMiddlewares the middlewares used by gin.Default.
+var ginDefaultMiddlewares = []string{
+	"github.com/gin-gonic/gin.Logger",
+	"github.com/gin-gonic/gin.Recovery",
+}
+
+// StaticRoutes finds the routes registered in the source of dirs without running the service,
+// e.g. app.Group("/api", auth).GET("/users/:id", api.GetUser) or mux.HandleFunc("GET /users/{id}", getUser).
+// The routers passed to the functions in the same module are followed, e.g. registerUserRoutes(v1).
+// Only the routes whose path is a constant are found, the handler is a declared function or method,
+// a closure, or a closure returned by a handler factory, e.g. app.GET("/users", api.ListUsers(db)).
+func StaticRoutes(dirs []string, buildTags []string) (RouteSource, error) {
+	fileSet := token.NewFileSet()
+	pkgs, err := loadPackages(fileSet, dirs, buildTags)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(funcIndex)
+	index.addPackages(fileSet, pkgs)
+
+	analyzer := newRouteAnalyzer(index)
+	// the package vars first, e.g. var router = gin.New()
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, declaration := range file.Decls {
+				if decl, ok := declaration.(*ast.GenDecl); ok && decl.Tok == token.VAR {
+					analyzer.analyze("", decl, pkg.TypesInfo)
+				}
+			}
+		}
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, declaration := range file.Decls {
+				decl, ok := declaration.(*ast.FuncDecl)
+				if !ok || decl.Body == nil {
+					continue
+				}
+				name := decl.Name.Name
+				if fn, ok := pkg.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+					name = fn.FullName()
+				}
+				analyzer.addClosures(decl, pkg.TypesInfo)
+				analyzer.analyze(name, decl.Body, pkg.TypesInfo)
+			}
+		}
+	}
+	routes := analyzer.result()
+
+	return RouteSourceFunc(func() []RouteInfo {
+		return routes
+	}), nil
+}
+
+// ginGroup a gin router with the prefix and the middlewares of the routes registered on it.
+type ginGroup struct {
+	prefix      string
+	middlewares []string
+	// owner the function whose param the group is, its routes are found only if no caller passes the group
+	owner string
+}
+
+func (group *ginGroup) child(prefix string, middlewares []string) *ginGroup {
+	return &ginGroup{
+		prefix:      joinPaths(group.prefix, prefix),
+		middlewares: append(append([]string{}, group.middlewares...), middlewares...),
+		owner:       group.owner,
+	}
+}
+
+// routeAnalyzer finds the routes registered in the functions, following the routers passed to the other functions.
+type routeAnalyzer struct {
+	index funcIndex
+	// the groups of the variables, params and fields
+	groups map[types.Object]*ginGroup
+	// the groups returned by the calls of the function being analyzed
+	calls map[*ast.CallExpr]*ginGroup
+	// the function being analyzed
+	current string
+	// the functions being followed, to break recursive calls
+	visiting map[string]bool
+	// the functions followed from a caller passing a router
+	followed map[string]bool
+	// the symbols of the closures in the analyzed functions, e.g. main.main.func1
+	closures map[*ast.FuncLit]string
+
+	routes []RouteInfo
+	// the routes registered on the groups of the params keyed by the function, e.g. func register(r *gin.RouterGroup)
+	unbound map[string][]RouteInfo
+	owners  []string
+}
+
+func newRouteAnalyzer(index funcIndex) *routeAnalyzer {
+	return &routeAnalyzer{
+		index:    index,
+		groups:   make(map[types.Object]*ginGroup),
+		visiting: make(map[string]bool),
+		followed: make(map[string]bool),
+		closures: make(map[*ast.FuncLit]string),
+		routes:   make([]RouteInfo, 0),
+		unbound:  make(map[string][]RouteInfo),
+		owners:   make([]string, 0),
+	}
+}
+
+// result returns the found routes, the routes of the params are included if the function is not followed from a caller.
+func (a *routeAnalyzer) result() []RouteInfo {
+	routes := a.routes
+	for _, owner := range a.owners {
+		if !a.followed[owner] {
+			routes = append(routes, a.unbound[owner]...)
+		}
+	}
+
+	results := make([]RouteInfo, 0, len(routes))
+	exist := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		key := route.Method + " " + route.Path + " " + route.Handler
+		if exist[key] {
+			continue
+		}
+		exist[key] = true
+		results = append(results, route)
+	}
+
+	return results
+}
+
+func (a *routeAnalyzer) analyze(name string, node ast.Node, info *types.Info) {
+	prevCurrent, prevCalls := a.current, a.calls
+	a.current, a.calls = name, make(map[*ast.CallExpr]*ginGroup)
+	defer func() {
+		a.current, a.calls = prevCurrent, prevCalls
+	}()
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch node := n.(type) {
+		// v1 := app.Group("/v1")
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				a.assign(lhs, node.Rhs[i], info)
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) != len(node.Values) {
+				return true
+			}
+			for i, name := range node.Names {
+				a.assign(name, node.Values[i], info)
+			}
+		case *ast.CallExpr:
+			if a.group(node, info) != nil {
+				return true
+			}
+			a.registerHTTP(node, info)
+			a.follow(node, info)
+		}
+
+		return true
+	})
+}
+
+func (a *routeAnalyzer) assign(lhs, rhs ast.Expr, info *types.Info) {
+	group := a.group(rhs, info)
+	if group == nil {
+		return
+	}
+
+	switch target := lhs.(type) {
+	case *ast.Ident:
+		obj := info.Defs[target]
+		if obj == nil {
+			obj = info.Uses[target]
+		}
+		if obj != nil {
+			a.groups[obj] = group
+		}
+	case *ast.SelectorExpr:
+		if obj := info.Uses[target.Sel]; obj != nil {
+			a.groups[obj] = group
+		}
+	}
+}
+
+// group returns the group of a gin router expression, the routes registered by the expression are recorded,
+// nil is returned if expr is not a gin router.
+func (a *routeAnalyzer) group(expr ast.Expr, info *types.Info) *ginGroup {
+	value, exist := info.Types[expr]
+	if !exist || value.Type == nil || !ginRouters[value.Type.String()] {
+		return nil
+	}
+
+	switch router := unparen(expr).(type) {
+	case *ast.Ident:
+		obj := info.Uses[router]
+		if group, exist := a.groups[obj]; exist {
+			return group
+		}
+		// a param or a captured variable, e.g. func register(r *gin.RouterGroup)
+		group := &ginGroup{owner: a.current}
+		if obj != nil {
+			a.groups[obj] = group
+		}
+		return group
+	case *ast.SelectorExpr:
+		obj := info.Uses[router.Sel]
+		if group, exist := a.groups[obj]; exist {
+			return group
+		}
+		group := &ginGroup{prefix: "/"}
+		if obj != nil {
+			a.groups[obj] = group
+		}
+		return group
+	case *ast.CallExpr:
+		if group, exist := a.calls[router]; exist {
+			return group
+		}
+		group := a.call(router, info)
+		a.calls[router] = group
+		return group
+	}
+
+	return &ginGroup{prefix: "/"}
+}
+
+// call returns the group returned by a call, the routes are recorded if it is a method of a gin router.
+func (a *routeAnalyzer) call(call *ast.CallExpr, info *types.Info) *ginGroup {
+	var parent *ginGroup
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if ok {
+		parent = a.group(selector.X, info)
+	}
+	if parent == nil {
+		// gin.New(), gin.Default() or a function returning a router
+		a.follow(call, info)
+		group := &ginGroup{prefix: "/"}
+		if fn := calleeFunc(call, info); fn != nil && funcSymbol(fn) == "github.com/gin-gonic/gin.Default" {
+			group.middlewares = append(group.middlewares, ginDefaultMiddlewares...)
+		}
+		return group
+	}
+
+	switch selector.Sel.Name {
+	case "Group":
+		if len(call.Args) == 0 {
+			return parent
+		}
+		prefix, ok := stringValue(call.Args[0], info)
+		if !ok {
+			return parent.child("", middlewareSymbols(call.Args[1:], info))
+		}
+		return parent.child(prefix, middlewareSymbols(call.Args[1:], info))
+	case "Use":
+		parent.middlewares = append(parent.middlewares, middlewareSymbols(call.Args, info)...)
+	default:
+		a.register(parent, selector.Sel.Name, call.Args, info)
+	}
+
+	return parent
+}
+
+// register records the routes registered by a method of a gin router, the handler is the last of the handlers.
+func (a *routeAnalyzer) register(group *ginGroup, name string, args []ast.Expr, info *types.Info) {
+	methods := []string{name}
+	switch name {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions:
+	case "Any":
+		methods = ginAnyMethods
+	case "Handle", "Match":
+		if len(args) == 0 {
+			return
+		}
+		methods = stringValues(args[0], info)
+		args = args[1:]
+	default:
+		return
+	}
+
+	if len(methods) == 0 || len(args) < 2 {
+		return
+	}
+	relativePath, ok := stringValue(args[0], info)
+	if !ok {
+		return
+	}
+	handlers := args[1:]
+	handler := a.handlerSymbol(handlers[len(handlers)-1], info)
+	if handler == "" {
+		return
+	}
+	middlewares := append(append([]string{}, group.middlewares...), middlewareSymbols(handlers[:len(handlers)-1], info)...)
+
+	for _, method := range methods {
+		route := RouteInfo{
+			Method:      strings.ToUpper(method),
+			Path:        joinPaths(group.prefix, relativePath),
+			Handler:     handler,
+			Middlewares: middlewares,
+			Group:       group.prefix,
+		}
+		if group.owner != "" {
+			if _, exist := a.unbound[group.owner]; !exist {
+				a.owners = append(a.owners, group.owner)
+			}
+			a.unbound[group.owner] = append(a.unbound[group.owner], route)
+			continue
+		}
+		a.routes = append(a.routes, route)
+	}
+}
+
+// registerHTTP records the routes registered to a http.ServeMux, e.g. http.HandleFunc("GET /users/{id}", getUser).
+func (a *routeAnalyzer) registerHTTP(call *ast.CallExpr, info *types.Info) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 2 || (selector.Sel.Name != "HandleFunc" && selector.Sel.Name != "Handle") {
+		return
+	}
+
+	var receiver string
+	if selection, exist := info.Selections[selector]; exist {
+		receiver = selection.Recv().String()
+	} else if ident, ok := selector.X.(*ast.Ident); ok {
+		if pkgName, ok := info.Uses[ident].(*types.PkgName); ok {
+			receiver = pkgName.Imported().Path()
+		}
+	}
+	if receiver != "net/http" && receiver != "*net/http.ServeMux" {
+		return
+	}
+
+	pattern, ok := stringValue(call.Args[0], info)
+	if !ok {
+		return
+	}
+	handler := a.handlerSymbol(call.Args[1], info)
+	if handler == "" {
+		return
+	}
+	method, path := splitPattern(pattern)
+	a.routes = append(a.routes, RouteInfo{Method: method, Path: path, Handler: handler})
+}
+
+// follow analyzes the callee with the routers passed to it, e.g. registerUserRoutes(v1).
+func (a *routeAnalyzer) follow(call *ast.CallExpr, info *types.Info) {
+	fn := calleeFunc(call, info)
+	if fn == nil {
+		return
+	}
+	name := fn.FullName()
+	source, exist := a.index[name]
+	if !exist || a.visiting[name] {
+		return
+	}
+
+	bindings := make(map[types.Object]*ginGroup)
+	index := 0
+	for _, field := range source.decl.Type.Params.List {
+		for _, param := range field.Names {
+			if index >= len(call.Args) {
+				break
+			}
+			if obj := source.info.Defs[param]; obj != nil {
+				if group := a.group(call.Args[index], info); group != nil {
+					bindings[obj] = group
+				}
+			}
+			index++
+		}
+	}
+	if len(bindings) == 0 {
+		return
+	}
+
+	previous := make(map[types.Object]*ginGroup, len(bindings))
+	for obj, group := range bindings {
+		previous[obj] = a.groups[obj]
+		a.groups[obj] = group
+	}
+	a.followed[name] = true
+	a.visiting[name] = true
+	a.addClosures(source.decl, source.info)
+	a.analyze(name, source.decl.Body, source.info)
+	delete(a.visiting, name)
+	for obj, group := range previous {
+		if group == nil {
+			delete(a.groups, obj)
+			continue
+		}
+		a.groups[obj] = group
+	}
+}
+
+// addClosures names the closures of a function the same as the compiler, e.g. main.main.func1.
+func (a *routeAnalyzer) addClosures(decl *ast.FuncDecl, info *types.Info) {
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok || decl.Body == nil {
+		return
+	}
+	symbol := funcSymbol(fn)
+	for _, closure := range funcLits(decl.Body) {
+		a.closures[closure.lit] = symbol + "." + closure.name
+	}
+}
+
+// handlerSymbol returns the symbol of a handler expression, the closures and the handler factories are included,
+// e.g. main.main.func1 for an inline closure, github.com/x/api.ListUsers.func1 for api.ListUsers(db).
+func (a *routeAnalyzer) handlerSymbol(expr ast.Expr, info *types.Info) string {
+	switch handler := unparen(expr).(type) {
+	case *ast.FuncLit:
+		return a.closures[handler]
+	case *ast.CallExpr:
+		fn := calleeFunc(handler, info)
+		if fn == nil {
+			return ""
+		}
+		source, exist := a.index[fn.FullName()]
+		if !exist {
+			return ""
+		}
+		returned := returnedFuncLit(source.decl.Body)
+		for _, closure := range funcLits(source.decl.Body) {
+			if closure.lit == returned {
+				return funcSymbol(fn) + "." + closure.name
+			}
+		}
+		return ""
+	}
+
+	return handlerSymbol(expr, info)
+}
+
+// middlewareSymbols returns the symbols of the middlewares, a middleware created by a call is named by the function called,
+// e.g. gin.BasicAuth(accounts) is github.com/gin-gonic/gin.BasicAuth.
+func middlewareSymbols(exprs []ast.Expr, info *types.Info) []string {
+	symbols := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		var symbol string
+		if call, ok := unparen(expr).(*ast.CallExpr); ok {
+			if fn := calleeFunc(call, info); fn != nil {
+				symbol = funcSymbol(fn)
+			}
+		} else {
+			symbol = handlerSymbol(expr, info)
+		}
+		if symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols
+}
+
+// handlerSymbol returns the symbol of a handler expression in the format of runtime,
+// e.g. main.SayHello, github.com/x/api.(*Controller).Get-fm for a method value.
+func handlerSymbol(expr ast.Expr, info *types.Info) string {
+	switch handler := unparen(expr).(type) {
+	case *ast.Ident:
+		if fn, ok := info.Uses[handler].(*types.Func); ok {
+			return funcSymbol(fn)
+		}
+	case *ast.SelectorExpr:
+		if selection, exist := info.Selections[handler]; exist {
+			fn, ok := selection.Obj().(*types.Func)
+			if !ok {
+				return ""
+			}
+			switch selection.Kind() {
+			case types.MethodVal:
+				return funcSymbol(fn) + "-fm"
+			case types.MethodExpr:
+				return funcSymbol(fn)
+			}
+			return ""
+		}
+		if fn, ok := info.Uses[handler.Sel].(*types.Func); ok {
+			return funcSymbol(fn)
+		}
+	}
+
+	return ""
+}
+
+// funcSymbol returns the symbol of a declared function or method in the format of runtime.
+func funcSymbol(fn *types.Func) string {
+	pkgPath := ""
+	if fn.Pkg() != nil {
+		pkgPath = fn.Pkg().Path()
+		// the functions of the main package are named main.xxx by runtime
+		if fn.Pkg().Name() == "main" {
+			pkgPath = "main"
+		}
+	}
+
+	signature, ok := fn.Type().(*types.Signature)
+	if !ok || signature.Recv() == nil {
+		return pkgPath + "." + fn.Name()
+	}
+
+	recv := signature.Recv().Type()
+	pointer := false
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv, pointer = ptr.Elem(), true
+	}
+	recvName := types.TypeString(recv, func(*types.Package) string { return "" })
+	if index := strings.Index(recvName, "["); index >= 0 {
+		recvName = recvName[:index]
+	}
+	if pointer {
+		recvName = "(*" + recvName + ")"
+	}
+
+	return pkgPath + "." + recvName + "." + fn.Name()
+}
+
+// joinPaths joins the prefix of a group and a relative path the same as gin.
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+
+	finalPath := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+
+	return finalPath
+}
+
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
+
+func stringValue(expr ast.Expr, info *types.Info) (string, bool) {
+	value, exist := info.Types[expr]
+	if !exist || value.Value == nil || value.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(value.Value), true
+}
+
+// stringValues returns the constant strings of a string or a []string literal, e.g. []string{http.MethodGet, "POST"}.
+func stringValues(expr ast.Expr, info *types.Info) []string {
+	if value, ok := stringValue(expr, info); ok {
+		return []string{value}
+	}
+
+	literal, ok := unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(literal.Elts))
+	for _, elt := range literal.Elts {
+		if value, ok := stringValue(elt, info); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStaticRoutes(t *testing.T) {
+	const pkg = "github.com/Scterl/go-swagger/parser/testdata/staticroutes."
+	defaults := []string{"github.com/gin-gonic/gin.Logger", "github.com/gin-gonic/gin.Recovery"}
+	auth := append(append([]string{}, defaults...), pkg+"Auth")
+
+	source, err := StaticRoutes([]string{"testdata/staticroutes"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := make(map[string]RouteInfo)
+	for _, route := range source.Routes() {
+		routes[route.Method+" "+route.Path] = route
+	}
+
+	tests := []struct {
+		name     string
+		route    string
+		expected RouteInfo
+	}{
+		{
+			name:     "group with a middleware and a constant path",
+			route:    "GET /api/v1/users",
+			expected: RouteInfo{Method: "GET", Path: "/api/v1/users", Handler: pkg + "ListUsers", Middlewares: auth, Group: "/api/v1"},
+		},
+		{
+			name:     "group passed to a function",
+			route:    "GET /api/v1/users/:id",
+			expected: RouteInfo{Method: "GET", Path: "/api/v1/users/:id", Handler: pkg + "(*Controller).Get-fm", Middlewares: auth, Group: "/api/v1/users"},
+		},
+		{
+			name:     "handler factory",
+			route:    "POST /api/v1/orders",
+			expected: RouteInfo{Method: "POST", Path: "/api/v1/orders", Handler: pkg + "ListOrders.func1", Middlewares: auth, Group: "/api/v1"},
+		},
+		{
+			name:     "inline closure",
+			route:    "GET /ping",
+			expected: RouteInfo{Method: "GET", Path: "/ping", Handler: pkg + "Register.func1", Middlewares: defaults, Group: "/"},
+		},
+		{
+			name:  "method value",
+			route: "GET /admin/users/:id",
+			expected: RouteInfo{
+				Method:      "GET",
+				Path:        "/admin/users/:id",
+				Handler:     pkg + "(*Controller).Get-fm",
+				Middlewares: append(append([]string{}, defaults...), "github.com/gin-gonic/gin.BasicAuth"),
+				Group:       "/admin",
+			},
+		},
+		{
+			name:     "ServeMux pattern",
+			route:    "GET /health",
+			expected: RouteInfo{Method: "GET", Path: "/health", Handler: pkg + "GetHealth"},
+		},
+	}
+
+	if len(routes) != len(tests) {
+		t.Errorf("StaticRoutes finds %d routes, expected %d", len(routes), len(tests))
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := routes[test.route]; !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("route %s = %+v, expected %+v", test.route, got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package binding
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Query struct {
+	Page int    `form:"page" binding:"required"`
+	Sort string `form:"sort"`
+}
+
+type URI struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type Header struct {
+	Token string `header:"X-Token"`
+}
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Form struct {
+	Name string `form:"name"`
+}
+
+func ListUsers(c *gin.Context) {
+	var query Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func GetUser(c *gin.Context) {
+	var uri URI
+	var header Header
+	_ = c.ShouldBindUri(&uri)
+	_ = c.ShouldBindHeader(&header)
+	c.Status(http.StatusOK)
+}
+
+func CreateUser(c *gin.Context) {
+	var user User
+	c.BindXML(&user)
+	c.Status(http.StatusCreated)
+}
+
+func Upload(c *gin.Context) {
+	file, _ := c.FormFile("avatar")
+	_ = file
+	_ = c.PostForm("nickname")
+	c.Status(http.StatusOK)
+}
+
+func Search(c *gin.Context) {
+	var form Form
+	_ = c.ShouldBind(&form)
+	c.Status(http.StatusOK)
+}
+
+func Save(c *gin.Context) {
+	var user User
+	_ = c.ShouldBind(&user)
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,49 @@
+package calls
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+func GetUser(c *gin.Context) {
+	var user User
+	if err := bindUser(c, &user); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func bindUser(c *gin.Context, user *User) error {
+	return c.ShouldBindJSON(user)
+}
+
+func respondError(c *gin.Context, status int, err error) {
+	abort(c, status, Error{Message: err.Error()})
+}
+
+func abort(c *gin.Context, status int, body Error) {
+	c.AbortWithStatusJSON(status, body)
+}
+
+// Retry calls itself, the recursive calls are followed once.
+func Retry(c *gin.Context) {
+	retry(c, 3)
+}
+
+func retry(c *gin.Context, times int) {
+	if times == 0 {
+		c.JSON(http.StatusOK, User{})
+		return
+	}
+	retry(c, times-1)
+}
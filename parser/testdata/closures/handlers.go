@@ -0,0 +1,29 @@
+package closures
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Controller struct{}
+
+func (ctl *Controller) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, User{})
+}
+
+func ListUsers(limit int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, []User{})
+	}
+}
+
+func Register(app *gin.Engine) {
+	app.DELETE("/users/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+}
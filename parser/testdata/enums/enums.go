@@ -0,0 +1,38 @@
+package enums
+
+type Status string
+
+const (
+	StatusActive Status = "active" // 正常
+	// StatusBanned 已封禁
+	StatusBanned Status = "banned"
+)
+
+const base = 10
+
+type Kind int
+
+const (
+	KindUser  Kind = base + iota // a user
+	KindGroup                    // a group
+	_
+	KindRobot
+)
+
+type Flag uint8
+
+const (
+	FlagRead Flag = 1 << iota
+	FlagWrite
+)
+
+type Level string
+
+const LevelUnknown Level = "unknown"
+
+const LevelDefault = LevelUnknown
+
+type Task struct {
+	Status Status `json:"status"`
+	Kind   Kind   `json:"kind" enums:"10,11"`
+}
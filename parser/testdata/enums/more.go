@@ -0,0 +1,5 @@
+package enums
+
+const KindAdmin = KindRobot + 1
+
+const FlagExec Flag = FlagWrite << 1
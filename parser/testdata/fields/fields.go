@@ -0,0 +1,33 @@
+package fields
+
+type Base struct {
+	ID        int    `json:"id"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	Name      string `json:"name"`
+}
+
+type Audit struct {
+	Name    string `json:"name"`
+	Creator string `json:"creator"`
+}
+
+type Owner struct {
+	Email string `json:"email"`
+}
+
+type Meta struct {
+	Version int `json:"version"`
+}
+
+type User struct {
+	Base
+	Audit
+	*Owner
+	Meta     `json:"meta"`
+	Extra    Meta    `json:",inline"`
+	Nickname *string `json:"nickname,omitempty"`
+	Password string  `json:"-"`
+	Dash     string  `json:"-,"`
+	Code     string  `json:"code" binding:"required"`
+	internal string
+}
@@ -0,0 +1,26 @@
+package generics
+
+import (
+	"github.com/Scterl/go-swagger/parser/testdata/qualified/models"
+	v2 "github.com/Scterl/go-swagger/parser/testdata/qualified/v2/models"
+)
+
+type Response[T any] struct {
+	Code int `json:"code"`
+	Data T   `json:"data"`
+}
+
+type Page[K comparable, V any] struct {
+	Items map[K]V `json:"items"`
+	Total int     `json:"total"`
+}
+
+type User struct {
+	Name string `json:"name"`
+}
+
+// the instances used by the tests
+var (
+	_ Response[models.User]
+	_ Response[v2.User]
+)
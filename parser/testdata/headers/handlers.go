@@ -0,0 +1,23 @@
+package headers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+func GinHeaders(c *gin.Context) {
+	_ = c.GetHeader("X-Token")
+	_, _ = c.Cookie("session")
+	c.Header(requestIDHeader, "id")
+	c.Status(http.StatusOK)
+}
+
+func HTTPHeaders(w http.ResponseWriter, r *http.Request) {
+	_ = r.Header.Get("Authorization")
+	_, _ = r.Cookie("session")
+	w.Header().Set("X-Rate-Limit", "10")
+	w.WriteHeader(http.StatusNoContent)
+}
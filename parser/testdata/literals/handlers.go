@@ -0,0 +1,29 @@
+package literals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string `json:"name"`
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"user":  User{},
+		"total": 1,
+		"ok":    true,
+	})
+}
+
+func ListTags(c *gin.Context) {
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"tags": []string{"a"},
+	})
+}
+
+func GetError(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, gin.H{"error": c.Query("reason")})
+}
@@ -0,0 +1,48 @@
+package marshalers
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Text struct {
+	Value string
+}
+
+func (t Text) MarshalText() ([]byte, error) {
+	return []byte(t.Value), nil
+}
+
+type Both struct {
+	Value string
+}
+
+func (b Both) MarshalText() ([]byte, error) {
+	return []byte(b.Value), nil
+}
+
+func (b Both) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Value)
+}
+
+type JSONOnly struct {
+	Value string `json:"value"`
+}
+
+func (j *JSONOnly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"value": j.Value})
+}
+
+// Coord is serialized as [lat, lng]
+// @swagger:type array,number
+type Coord struct {
+	Lat, Lng float64
+}
+
+func (c Coord) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{c.Lat, c.Lng})
+}
+
+type Embedded struct {
+	time.Time
+}
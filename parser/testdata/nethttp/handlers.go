@@ -0,0 +1,43 @@
+package nethttp
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const keyName = "name"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+func CreateUser(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(Error{Message: err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(user)
+}
+
+func GetUser(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("id") == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(User{})
+}
+
+func SearchUsers(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get(keyName)
+	_ = r.URL.Query().Get(name)
+	_ = r.FormValue(name)
+	_ = r.PostForm.Get("page")
+	_ = r.Form.Get("size")
+	w.Write([]byte(name))
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Scterl/go-swagger/parser/testdata/qualified/models"
+	v2 "github.com/Scterl/go-swagger/parser/testdata/qualified/v2/models"
+)
+
+type Page struct {
+	Total int `json:"total"`
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(http.StatusOK, &models.User{})
+}
+
+func GetUserV2(c *gin.Context) {
+	var user v2.User
+	_ = c.ShouldBindJSON(&user)
+	c.JSON(http.StatusOK, []v2.User{user})
+}
+
+func GetOrder(c *gin.Context) {
+	c.JSON(http.StatusOK, findOrder())
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(http.StatusOK, map[string]Page{})
+}
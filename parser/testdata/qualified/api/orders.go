@@ -0,0 +1,7 @@
+package api
+
+import "github.com/Scterl/go-swagger/parser/testdata/qualified/orders"
+
+func findOrder() orders.Order {
+	return orders.Order{}
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func GetXML(c *gin.Context) {
+	c.XML(http.StatusOK, User{})
+}
+
+func GetYAML(c *gin.Context) {
+	c.YAML(http.StatusOK, User{})
+}
+
+func GetIndented(c *gin.Context) {
+	c.IndentedJSON(http.StatusCreated, []User{})
+}
+
+func GetString(c *gin.Context) {
+	c.String(http.StatusOK, "hello %s", c.Query("name"))
+}
+
+func GetImage(c *gin.Context) {
+	c.Data(http.StatusOK, "image/png", []byte{})
+}
+
+func Download(c *gin.Context) {
+	c.File("users.csv")
+}
+
+func Redirect(c *gin.Context) {
+	c.Redirect(http.StatusFound, "/users")
+}
+
+func Delete(c *gin.Context) {
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,28 @@
+package routers
+
+import "net/http"
+
+// Stale lists the users.
+// @Router /old [get]
+func Stale(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("users"))
+}
+
+// Kept gets the user.
+// @Router /users/{id} [get]
+func Kept(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(r.PathValue("id")))
+}
+
+// Partial lists the items.
+// @Router /items [get]
+// @Router /legacy/items [get]
+func Partial(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("items"))
+}
+
+// Unrouted is not routed.
+// @Router /unrouted [get]
+func Unrouted(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("unrouted"))
+}
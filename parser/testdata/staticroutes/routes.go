@@ -0,0 +1,47 @@
+package staticroutes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const usersPath = "/users"
+
+type Controller struct{}
+
+func (ctl *Controller) Get(c *gin.Context) {}
+
+func ListUsers(c *gin.Context) {}
+
+func ListOrders(limit int) gin.HandlerFunc {
+	return func(c *gin.Context) {}
+}
+
+func Auth() gin.HandlerFunc {
+	return func(c *gin.Context) {}
+}
+
+func GetHealth(w http.ResponseWriter, r *http.Request) {}
+
+func Register() {
+	app := gin.Default()
+	ctl := &Controller{}
+
+	api := app.Group("/api/v1", Auth())
+	api.GET(usersPath, ListUsers)
+	registerUsers(api.Group(usersPath))
+	api.POST("/orders", ListOrders(10))
+	app.GET("/ping", func(c *gin.Context) {})
+
+	accounts := app.Group("/admin", gin.BasicAuth(gin.Accounts{"admin": "secret"}))
+	accounts.GET("/users/:id", ctl.Get)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", GetHealth)
+}
+
+func registerUsers(users *gin.RouterGroup) {
+	ctl := &Controller{}
+	users.GET("/:id", ctl.Get)
+}
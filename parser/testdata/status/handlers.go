@@ -0,0 +1,35 @@
+package status
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const StatusAccepted = http.StatusAccepted
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+func CreateUser(c *gin.Context) {
+	if c.Query("async") != "" {
+		c.JSON(StatusAccepted, User{})
+		return
+	}
+	code := http.StatusConflict
+	c.JSON(code, Error{})
+}
+
+func GetUser(c *gin.Context) {
+	status := http.StatusOK
+	var user interface{} = User{}
+	if c.Query("missing") != "" {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, user)
+}
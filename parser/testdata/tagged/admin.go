@@ -0,0 +1,8 @@
+//go:build admin
+
+package tagged
+
+type Admin struct {
+	User
+	Role string `json:"role"`
+}
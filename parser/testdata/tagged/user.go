@@ -0,0 +1,5 @@
+package tagged
+
+type User struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,23 @@
+package wellknown
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+type Money struct {
+	Units int64
+	Nanos int32
+}
+
+type Account struct {
+	CreatedAt time.Time       `json:"createdAt"`
+	Timeout   *time.Duration  `json:"timeout"`
+	Extra     json.RawMessage `json:"extra"`
+	Nickname  sql.NullString  `json:"nickname"`
+	Balance   big.Int         `json:"balance"`
+	Price     Money           `json:"price"`
+	Alias     sql.NullString  `json:"alias" extensions:"x-Order-Key=1"`
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// WellKnownTypes the schemas of the well-known types keyed by their names qualified by the import paths,
+// e.g. github.com/google/uuid.UUID. The mappings of a parser set by SetTypeMappings take precedence.
+var WellKnownTypes = map[string]spec.Schema{
+	"any":                      {},
+	"time.Time":                *formatSchema(STRING, "date-time"),
+	"time.Duration":            *formatSchema(INTEGER, "int64"),
+	"encoding/json.RawMessage": {},
+	"encoding/json.Number":     *PrimitiveSchema(NUMBER),
+	"net.IP":                   *formatSchema(STRING, "ip"),
+	"net/netip.Addr":           *formatSchema(STRING, "ip"),
+	"math/big.Int":             *PrimitiveSchema(INTEGER),
+	"math/big.Float":           *PrimitiveSchema(STRING),
+	"math/big.Rat":             *PrimitiveSchema(STRING),
+
+	"database/sql.NullString":  *nullableSchema(PrimitiveSchema(STRING)),
+	"database/sql.NullBool":    *nullableSchema(PrimitiveSchema(BOOLEAN)),
+	"database/sql.NullByte":    *nullableSchema(PrimitiveSchema(INTEGER)),
+	"database/sql.NullInt16":   *nullableSchema(formatSchema(INTEGER, "int32")),
+	"database/sql.NullInt32":   *nullableSchema(formatSchema(INTEGER, "int32")),
+	"database/sql.NullInt64":   *nullableSchema(formatSchema(INTEGER, "int64")),
+	"database/sql.NullFloat64": *nullableSchema(formatSchema(NUMBER, "double")),
+	"database/sql.NullTime":    *nullableSchema(formatSchema(STRING, "date-time")),
+
+	"github.com/google/uuid.UUID":                         *formatSchema(STRING, "uuid"),
+	"github.com/google/uuid.NullUUID":                     *nullableSchema(formatSchema(STRING, "uuid")),
+	"github.com/gofrs/uuid.UUID":                          *formatSchema(STRING, "uuid"),
+	"github.com/gofrs/uuid.NullUUID":                      *nullableSchema(formatSchema(STRING, "uuid")),
+	"github.com/satori/go.uuid.UUID":                      *formatSchema(STRING, "uuid"),
+	"github.com/shopspring/decimal.Decimal":               *formatSchema(STRING, "decimal"),
+	"github.com/shopspring/decimal.NullDecimal":           *nullableSchema(formatSchema(STRING, "decimal")),
+	"go.mongodb.org/mongo-driver/bson/primitive.ObjectID": *PrimitiveSchema(STRING),
+	"go.mongodb.org/mongo-driver/v2/bson.ObjectID":        *PrimitiveSchema(STRING),
+	"github.com/jackc/pgx/v5/pgtype.Text":                 *nullableSchema(PrimitiveSchema(STRING)),
+	"github.com/jackc/pgx/v5/pgtype.Timestamptz":          *nullableSchema(formatSchema(STRING, "date-time")),
+}
+
+// SetTypeMappings maps the types to their schemas, the types are named by the import paths of their packages,
+// e.g. github.com/x/money.Amount, the mappings take precedence over WellKnownTypes.
+func SetTypeMappings(mappings map[string]spec.Schema) func(*Parser) {
+	return func(p *Parser) {
+		if p.typeMappings == nil {
+			p.typeMappings = make(map[string]spec.Schema, len(mappings))
+		}
+		for typeName, schema := range mappings {
+			p.typeMappings[typeName] = schema
+		}
+	}
+}
+
+// wellKnownSchema returns a copy of the schema of the well-known type used in file, e.g. uuid.UUID,
+// nil if the type is not mapped.
+func (parser *Parser) wellKnownSchema(typeName string, file *ast.File) *spec.Schema {
+	typeName = parser.packages.qualifiedTypeName(typeName, file)
+	// the types of the package of file are named without the package, e.g. Amount in github.com/x/money
+	if info, ok := parser.packages.files[file]; ok && !strings.Contains(typeName, ".") && !IsGolangPrimitiveType(typeName) {
+		if schema, exist := parser.typeMappings[info.PackagePath+"."+typeName]; exist {
+			return copySchema(&schema)
+		}
+	}
+	schema, exist := parser.typeMappings[typeName]
+	if !exist {
+		schema, exist = WellKnownTypes[typeName]
+	}
+	if !exist {
+		return nil
+	}
+
+	// the schemas of the fields are changed by their tags
+	return copySchema(&schema)
+}
+
+// qualifiedTypeName returns the name of a type used in file qualified by the import path of its package,
+// e.g. github.com/google/uuid.UUID for uuid.UUID, the name itself if the package is not imported by file.
+func (pkgs *PackagesDefinitions) qualifiedTypeName(typeName string, file *ast.File) string {
+	pkgName, name, found := strings.Cut(typeName, ".")
+	if !found || strings.Contains(typeName, "/") || file == nil {
+		return typeName
+	}
+
+	qualified := typeName
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == pkgName {
+				return path + "." + name
+			}
+			continue
+		}
+		segments := strings.Split(path, "/")
+		last := segments[len(segments)-1]
+		// the major version suffix, e.g. github.com/x/foo/v2
+		if len(segments) > 1 && len(last) > 1 && last[0] == 'v' && strings.Trim(last[1:], "0123456789") == "" {
+			last = segments[len(segments)-2]
+		}
+		if last == pkgName {
+			return path + "." + name
+		}
+		// the package names differ from the paths, e.g. uuid of github.com/satori/go.uuid, null of gopkg.in/guregu/null.v4
+		if qualified == typeName && strings.Contains(last, pkgName) {
+			qualified = path + "." + name
+		}
+	}
+
+	return qualified
+}
+
+// copySchema returns a copy of schema whose type and extensions can be changed.
+func copySchema(schema *spec.Schema) *spec.Schema {
+	copied := *schema
+	copied.Type = append(spec.StringOrArray(nil), schema.Type...)
+	if schema.Extensions != nil {
+		copied.Extensions = make(spec.Extensions, len(schema.Extensions))
+		for key, value := range schema.Extensions {
+			copied.Extensions[key] = value
+		}
+	}
+
+	return &copied
+}
+
+func formatSchema(schemaType, format string) *spec.Schema {
+	schema := PrimitiveSchema(schemaType)
+	schema.Format = format
+
+	return schema
+}
+
+func nullableSchema(schema *spec.Schema) *spec.Schema {
+	schema.AddExtension("x-nullable", true)
+
+	return schema
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func TestWellKnownSchema(t *testing.T) {
+	parser, file := newTypesParser(t, "testdata/wellknown")
+	SetTypeMappings(map[string]spec.Schema{
+		"github.com/Scterl/go-swagger/parser/testdata/wellknown.Money": *formatSchema(STRING, "decimal"),
+		"time.Time": *PrimitiveSchema(INTEGER),
+	})(parser)
+
+	schema, err := parser.getTypeSchema("wellknown.Account", file, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "createdAt", expected: `{"type":"integer"}`},
+		{field: "timeout", expected: `{"type":"integer","format":"int64","x-nullable":true}`},
+		{field: "extra", expected: `{}`},
+		{field: "nickname", expected: `{"type":"string","x-nullable":true}`},
+		{field: "balance", expected: `{"type":"integer"}`},
+		{field: "alias", expected: `{"type":"string","x-Order-Key":"1","x-nullable":true}`},
+		{field: "price", expected: `{"type":"string","format":"decimal"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			property, exist := schema.Properties[test.field]
+			if !exist {
+				t.Fatalf("field %s is not found", test.field)
+			}
+			got, err := json.Marshal(property)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.expected {
+				t.Errorf("schema of field %s = %s, expected %s", test.field, got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// diffContext the number of the unchanged lines around a change in the diff.
+const diffContext = 3
+
+// swaggerCommentRegExp matches the swagger annotations of an operation written back.
+var swaggerCommentRegExp = regexp.MustCompile(`^//\s*@(Summary|Description|ID|Tags|Accept|Produce|Param|Security|Success|Failure|Response|Header|Router)(\s|$)`)
+
+// textEdit replaces the lines [start, end) of a file, the offsets are at the beginning of lines.
+type textEdit struct {
+	start int
+	end   int
+	lines []string
+}
+
+// generatedComments returns the comments of the functions keyed by their names, e.g. api.Controller.Get.
+func generatedComments(functions []FunctionDesc) map[string][]string {
+	comments := make(map[string][]string, len(functions))
+	for index := range functions {
+		function := &functions[index]
+		texts := make([]string, 0)
+		if function.source.Doc != nil {
+			for _, comment := range function.source.Doc.List {
+				texts = append(texts, comment.Text)
+			}
+		}
+		comments[function.PackageName] = texts
+	}
+
+	return comments
+}
+
+// writeBack rewrites the doc comments of the handlers in the file on disk with the generated swagger comments,
+// the other comments of the handlers are kept before the swagger comments. If dryRun the diff is written to out
+// instead, it returns whether the file is changed.
+func writeBack(path string, comments map[string][]string, dryRun bool, out io.Writer) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	fileSet := token.NewFileSet()
+	tree, err := goparser.ParseFile(fileSet, path, src, goparser.ParseComments)
+	if err != nil {
+		return false, err
+	}
+	file := fileSet.File(tree.Package)
+
+	edits := make([]textEdit, 0)
+	for _, declaration := range tree.Decls {
+		decl, ok := declaration.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		generated, exist := comments[funcPackageName(tree, decl)]
+		if !exist {
+			continue
+		}
+
+		lines := make([]string, 0)
+		if decl.Doc != nil {
+			for _, comment := range decl.Doc.List {
+				if !isSwaggerComment(comment.Text) {
+					lines = append(lines, comment.Text)
+				}
+			}
+		}
+		for _, comment := range generated {
+			if isSwaggerComment(comment) {
+				lines = append(lines, comment)
+			}
+		}
+
+		edit := textEdit{
+			start: file.Offset(file.LineStart(file.Line(decl.Pos()))),
+			lines: lines,
+		}
+		edit.end = edit.start
+		if decl.Doc != nil {
+			edit.start = file.Offset(file.LineStart(file.Line(decl.Doc.Pos())))
+			edit.end = file.Offset(file.LineStart(file.Line(decl.Doc.End()) + 1))
+		}
+		if edit, changed := trimEdit(src, edit); changed {
+			edits = append(edits, edit)
+		}
+	}
+
+	if len(edits) == 0 {
+		return false, nil
+	}
+
+	if dryRun {
+		name := path
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				name = filepath.ToSlash(rel)
+			}
+		}
+		_, err := io.WriteString(out, unifiedDiff(name, src, edits))
+		return true, err
+	}
+
+	var buffer bytes.Buffer
+	offset := 0
+	for _, edit := range edits {
+		buffer.Write(src[offset:edit.start])
+		buffer.WriteString(joinLines(edit.lines))
+		offset = edit.end
+	}
+	buffer.Write(src[offset:])
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+
+	return true, os.WriteFile(path, buffer.Bytes(), info.Mode())
+}
+
+// trimEdit shrinks the edit by the unchanged lines at its beginning and end, it reports whether the edit changes anything.
+func trimEdit(src []byte, edit textEdit) (textEdit, bool) {
+	oldLines := strings.SplitAfter(string(src[edit.start:edit.end]), "\n")
+	if len(oldLines) > 0 && oldLines[len(oldLines)-1] == "" {
+		oldLines = oldLines[:len(oldLines)-1]
+	}
+
+	for len(oldLines) > 0 && len(edit.lines) > 0 && oldLines[0] == edit.lines[0]+"\n" {
+		edit.start += len(oldLines[0])
+		oldLines = oldLines[1:]
+		edit.lines = edit.lines[1:]
+	}
+	for len(oldLines) > 0 && len(edit.lines) > 0 && oldLines[len(oldLines)-1] == edit.lines[len(edit.lines)-1]+"\n" {
+		edit.end -= len(oldLines[len(oldLines)-1])
+		oldLines = oldLines[:len(oldLines)-1]
+		edit.lines = edit.lines[:len(edit.lines)-1]
+	}
+
+	return edit, len(oldLines) > 0 || len(edit.lines) > 0
+}
+
+// isSwaggerComment reports whether the comment is a swagger annotation of an operation, the other comments starting
+// with @, e.g. @todo, are not generated.
+func isSwaggerComment(comment string) bool {
+	return swaggerCommentRegExp.MatchString(comment)
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// unifiedDiff formats the edits of a file as an unified diff.
+func unifiedDiff(name string, src []byte, edits []textEdit) string {
+	oldLines := strings.SplitAfter(string(src), "\n")
+	if len(oldLines) > 0 && oldLines[len(oldLines)-1] == "" {
+		oldLines = oldLines[:len(oldLines)-1]
+	}
+	// the line index of the offsets at the beginning of lines
+	lineIndex := make(map[int]int, len(oldLines)+1)
+	offset := 0
+	for index, line := range oldLines {
+		lineIndex[offset] = index
+		offset += len(line)
+	}
+	lineIndex[offset] = len(oldLines)
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- a/%s\n+++ b/%s\n", name, name)
+
+	delta := 0
+	for i := 0; i < len(edits); {
+		// group the edits whose contexts overlap into a hunk
+		j := i + 1
+		for j < len(edits) && lineIndex[edits[j].start]-diffContext <= lineIndex[edits[j-1].end]+diffContext {
+			j++
+		}
+
+		hunkStart := lineIndex[edits[i].start] - diffContext
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := lineIndex[edits[j-1].end] + diffContext
+		if hunkEnd > len(oldLines) {
+			hunkEnd = len(oldLines)
+		}
+
+		var hunk strings.Builder
+		added, removed := 0, 0
+		line := hunkStart
+		for _, edit := range edits[i:j] {
+			for ; line < lineIndex[edit.start]; line++ {
+				hunk.WriteString(" " + withNewline(oldLines[line]))
+			}
+			for ; line < lineIndex[edit.end]; line++ {
+				hunk.WriteString("-" + withNewline(oldLines[line]))
+				removed++
+			}
+			for _, newLine := range edit.lines {
+				hunk.WriteString("+" + newLine + "\n")
+				added++
+			}
+		}
+		for ; line < hunkEnd; line++ {
+			hunk.WriteString(" " + withNewline(oldLines[line]))
+		}
+
+		oldCount := hunkEnd - hunkStart
+		newCount := oldCount - removed + added
+		fmt.Fprintf(&builder, "@@ -%d,%d +%d,%d @@\n", hunkStart+1, oldCount, hunkStart+1+delta, newCount)
+		builder.WriteString(hunk.String())
+
+		delta += added - removed
+		i = j
+	}
+
+	return builder.String()
+}
+
+func withNewline(line string) string {
+	if strings.HasSuffix(line, "\n") {
+		return line
+	}
+
+	return line + "\n"
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+const writeBackSource = `package api
+
+// CreateUser creates the user.
+// @todo validate the user
+// @deprecated use CreateUsers
+// @Summary old
+func CreateUser() {}
+
+func GetUser() {}
+`
+
+func TestWriteBack(t *testing.T) {
+	comments := map[string][]string{
+		"api.CreateUser": {
+			"// @todo validate the user",
+			"// @Summary CreateUser",
+			"// @Router /users [POST] api.CreateUser",
+		},
+		"api.GetUser": {
+			"// @Summary GetUser",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		dryRun   bool
+		expected string
+	}{
+		{
+			name:   "written",
+			dryRun: false,
+			expected: `package api
+
+// CreateUser creates the user.
+// @todo validate the user
+// @deprecated use CreateUsers
+// @Summary CreateUser
+// @Router /users [POST] api.CreateUser
+func CreateUser() {}
+
+// @Summary GetUser
+func GetUser() {}
+`,
+		},
+		{
+			name:   "diff",
+			dryRun: true,
+			expected: `--- a/api.go
++++ b/api.go
+@@ -3,7 +3,9 @@
+ // CreateUser creates the user.
+ // @todo validate the user
+ // @deprecated use CreateUsers
+-// @Summary old
++// @Summary CreateUser
++// @Router /users [POST] api.CreateUser
+ func CreateUser() {}
+ 
++// @Summary GetUser
+ func GetUser() {}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the diff names the file relative to the working directory
+			t.Chdir(t.TempDir())
+			path := "api.go"
+			if err := os.WriteFile(path, []byte(writeBackSource), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			changed, err := writeBack(path, comments, test.dryRun, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !changed {
+				t.Fatal("writeBack reports the file unchanged")
+			}
+
+			got := out.String()
+			if !test.dryRun {
+				src, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = string(src)
+			}
+			if got != test.expected {
+				t.Errorf("writeBack = %s, expected %s", got, test.expected)
+			}
+		})
+	}
+}